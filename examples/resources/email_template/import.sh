@@ -0,0 +1,20 @@
+# Email templates can be imported by their ID, shown in the Infobip web interface.
+terraform import pocinfobipemails_email_template.welcome_email 205000000016125
+
+# Appending @ and the expected template name makes the import fail unless the
+# template has exactly that name, guarding against a mistyped or copy-pasted ID.
+terraform import pocinfobipemails_email_template.welcome_email '205000000016125@Welcome email'
+
+# Every template of the account can be imported with import blocks, one per
+# template, and their configuration generated by Terraform:
+#
+#   data "pocinfobipemails_email_templates" "all" {}
+#
+#   import {
+#     for_each = { for t in data.pocinfobipemails_email_templates.all.templates : t.id => t }
+#     to       = pocinfobipemails_email_template.imported[each.key]
+#     id       = each.key
+#   }
+#
+# followed by:
+terraform plan -generate-config-out=generated.tf
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	// accountPath returns the account of the API key, whose enabled field is
+	// false while the account is suspended.
+	accountPath = "/settings/1/accounts/_"
+	// accountBalancePath returns the remaining balance of the account.
+	accountBalancePath = "/account/1/balance"
+)
+
+// infobipAccount is the part of the account response the status check uses.
+type infobipAccount struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// infobipAccountBalance is the part of the balance response the status
+// check uses.
+type infobipAccountBalance struct {
+	Balance  *float64 `json:"balance"`
+	Currency string   `json:"currency"`
+}
+
+// checkAccountActive fails when the Infobip account of the API key is
+// suspended, since templates of such an account cannot be sent, and with
+// checkBalance when it has no balance left. Postpaid accounts may have none,
+// so the balance is only checked on request. The SDK has no account API, so
+// the endpoints are called with client directly, authenticated as the SDK
+// would with auth. A status endpoint that cannot be called or answers
+// unexpectedly skips that part of the check with a warning.
+func checkAccountActive(auth context.Context, client *http.Client, baseURL, schemeKey string, checkActive, checkBalance bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if checkActive {
+		diags.Append(checkAccountEnabled(auth, client, baseURL, schemeKey)...)
+	}
+	if checkBalance {
+		diags.Append(checkAccountBalance(auth, client, baseURL, schemeKey)...)
+	}
+
+	return diags
+}
+
+// checkAccountEnabled fails when the account of the API key is suspended.
+func checkAccountEnabled(auth context.Context, client *http.Client, baseURL, schemeKey string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var account infobipAccount
+	if err := getAccountJSON(auth, client, baseURL+accountPath, schemeKey, &account); err != nil || account.Enabled == nil {
+		if err == nil {
+			err = fmt.Errorf("the response has no enabled field")
+		}
+		addAccountCheckSkipped(&diags, "require_active_account", "suspension", err)
+	} else if !*account.Enabled {
+		diags.AddAttributeError(
+			path.Root("require_active_account"),
+			"Infobip Account Suspended",
+			"The Infobip account of the API key is suspended, so emails sent with its templates would not be delivered. "+
+				"Contact Infobip support to reactivate the account, or unset require_active_account to manage templates anyway.",
+		)
+	}
+
+	return diags
+}
+
+// checkAccountBalance fails when the account of the API key has no balance
+// left.
+func checkAccountBalance(auth context.Context, client *http.Client, baseURL, schemeKey string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var balance infobipAccountBalance
+	if err := getAccountJSON(auth, client, baseURL+accountBalancePath, schemeKey, &balance); err != nil || balance.Balance == nil {
+		if err == nil {
+			err = fmt.Errorf("the response has no balance field")
+		}
+		addAccountCheckSkipped(&diags, "require_positive_balance", "balance", err)
+	} else if *balance.Balance <= 0 {
+		diags.AddAttributeError(
+			path.Root("require_positive_balance"),
+			"Infobip Account Over Quota",
+			fmt.Sprintf("The Infobip account of the API key has a balance of %g %s, so emails sent with its templates would not be delivered. "+
+				"Top up the account, or unset require_positive_balance to manage templates anyway.", *balance.Balance, balance.Currency),
+		)
+	}
+
+	return diags
+}
+
+// getAccountJSON decodes the JSON body of a GET request to url into v. The
+// request carries the Authorization header the SDK sets from the API key
+// registered under schemeKey in auth.
+func getAccountJSON(auth context.Context, client *http.Client, url, schemeKey string, v any) error {
+	req, err := http.NewRequestWithContext(auth, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if keys, ok := auth.Value(infobip.ContextAPIKeys).(map[string]infobip.APIKey); ok {
+		if apiKey, ok := keys[schemeKey]; ok {
+			if apiKey.Prefix != "" {
+				req.Header.Set("Authorization", apiKey.Prefix+" "+apiKey.Key)
+			} else {
+				req.Header.Set("Authorization", apiKey.Key)
+			}
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// addAccountCheckSkipped warns that the named account status check, enabled
+// by attribute, was skipped because of err.
+func addAccountCheckSkipped(diags *diag.Diagnostics, attribute, check string, err error) {
+	diags.AddAttributeWarning(
+		path.Root(attribute),
+		"Infobip Account Status Check Skipped",
+		fmt.Sprintf("The account %s check of %s was skipped, as the status endpoint is unavailable: %s", check, attribute, err),
+	)
+}
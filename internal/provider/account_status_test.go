@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_RequireActiveAccount(t *testing.T) {
+	activeOnly := map[string]tftypes.Value{"require_active_account": tfBool(true)}
+	withBalance := map[string]tftypes.Value{"require_active_account": tfBool(true), "require_positive_balance": tfBool(true)}
+	cases := map[string]struct {
+		account        *mockAccount
+		providerConfig map[string]tftypes.Value
+		error          string
+		warning        string
+	}{
+		"active": {
+			account:        &mockAccount{enabled: true, balance: 42.5},
+			providerConfig: withBalance,
+		},
+		"suspended": {
+			account:        &mockAccount{enabled: false, balance: 42.5},
+			providerConfig: activeOnly,
+			error:          "Infobip Account Suspended",
+		},
+		"postpaid": {
+			account:        &mockAccount{enabled: true, balance: 0},
+			providerConfig: activeOnly,
+		},
+		"over quota": {
+			account:        &mockAccount{enabled: true, balance: 0},
+			providerConfig: withBalance,
+			error:          "Infobip Account Over Quota",
+		},
+		"status endpoint unavailable": {
+			providerConfig: withBalance,
+			warning:        "Infobip Account Status Check Skipped",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			mock.account = tc.account
+
+			_, diags := configureTestHarness(t, mock, tc.providerConfig)
+			got := diagnosticsString(diags)
+			if tc.error == "" && hasError(diags) {
+				t.Fatalf("ConfigureProvider diagnostics: %s", got)
+			}
+			if tc.error != "" && (!hasError(diags) || !strings.Contains(got, tc.error)) {
+				t.Fatalf("diagnostics = %s, want the error %q", got, tc.error)
+			}
+			if tc.warning != "" && !strings.Contains(got, tc.warning) {
+				t.Fatalf("diagnostics = %s, want the warning %q", got, tc.warning)
+			}
+		})
+	}
+}
+
+func TestProvider_RequireActiveAccountUnset(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.account = &mockAccount{enabled: false}
+	newTestHarness(t, mock, nil)
+
+	for _, request := range mock.requests {
+		if strings.HasSuffix(request, accountPath) || strings.HasSuffix(request, accountBalancePath) {
+			t.Errorf("unexpected request %s without require_active_account", request)
+		}
+	}
+}
+
+func TestProvider_RequireActiveAccountAuthentication(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.account = &mockAccount{enabled: true, balance: 42.5}
+	accountHeaders := map[string]http.Header{}
+	mock.onRequest = func(r *http.Request) {
+		if r.URL.Path == accountPath || r.URL.Path == accountBalancePath {
+			accountHeaders[r.URL.Path] = r.Header.Clone()
+		}
+	}
+
+	_, diags := configureTestHarness(t, mock, map[string]tftypes.Value{
+		"require_active_account":   tfBool(true),
+		"require_positive_balance": tfBool(true),
+		"organization_id":          tfString("org-42"),
+	})
+	if hasError(diags) {
+		t.Fatalf("ConfigureProvider diagnostics: %s", diagnosticsString(diags))
+	}
+
+	for _, p := range []string{accountPath, accountBalancePath} {
+		header, ok := accountHeaders[p]
+		if !ok {
+			t.Errorf("no request to %s", p)
+			continue
+		}
+		if got, want := header.Get("Authorization"), mock.firstAuthorization(); got != want {
+			t.Errorf("%s Authorization = %q, want the client's %q", p, got, want)
+		}
+		if got := header.Get(organizationHeader); got != "org-42" {
+			t.Errorf("%s %s = %q, want %q", p, organizationHeader, got, "org-42")
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// loadAllowedFromDomains reads the newline-delimited domains of an
+// allowed_from_domains_file. Blank lines and lines starting with # are
+// skipped, and domains are lower-cased. A file without domains is an error,
+// since it would reject every template.
+func loadAllowedFromDomains(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		domain := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if domain == "" || strings.HasPrefix(domain, "#") {
+			continue
+		}
+		if strings.ContainsAny(domain, " \t@/") {
+			return nil, fmt.Errorf("line %d: %q is not a domain", line, domain)
+		}
+		domains = append(domains, strings.TrimPrefix(domain, "."))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("%s lists no domains", path)
+	}
+
+	return domains, nil
+}
+
+// fromDomainAllowed reports whether domain is one of allowed or a subdomain
+// of one.
+func fromDomainAllowed(domain string, allowed []string) bool {
+	for _, suffix := range allowed {
+		if domain == suffix || strings.HasSuffix(domain, "."+suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkAllowedFromDomain fails the plan when the domain of from is not in
+// allowed. Unknown and null addresses are left to apply time.
+func checkAllowedFromDomain(from types.String, allowed []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(allowed) == 0 || from.IsUnknown() || from.IsNull() {
+		return diags
+	}
+
+	domain := addressDomain(emailAddress(from.ValueString()))
+	if !fromDomainAllowed(domain, allowed) {
+		diags.AddAttributeError(
+			path.Root("from"),
+			"From Domain Not Allowed",
+			fmt.Sprintf("The domain of from, %q, is not listed in the provider's allowed_from_domains_file, nor a subdomain of a listed domain. "+
+				"Use an allowed sender domain, or have it added to the list.", domain),
+		)
+	}
+
+	return diags
+}
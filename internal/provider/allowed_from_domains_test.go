@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func writeAllowedFromDomains(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "allowed-domains.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing allowed domains: %s", err)
+	}
+
+	return path
+}
+
+func TestAllowedFromDomains(t *testing.T) {
+	file := writeAllowedFromDomains(t, "# Sender domains\nexample.com\n\n  Acme.IO  \n")
+
+	cases := map[string]struct {
+		from    string
+		allowed bool
+	}{
+		"listed domain":    {from: "Sender <sender@example.com>", allowed: true},
+		"listed subdomain": {from: "news@mail.acme.io", allowed: true},
+		"unlisted domain":  {from: "Sender <sender@example.org>"},
+		"lookalike suffix": {from: "sender@notexample.com"},
+		"parent domain":    {from: "sender@com"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, map[string]tftypes.Value{"allowed_from_domains_file": tfString(file)})
+			r := h.resource("pocinfobipemails_email_template")
+
+			diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"from": tfString(tc.from)}))
+			if tc.allowed && hasError(diags) {
+				t.Fatalf("apply: %s", diagnosticsString(diags))
+			}
+			if !tc.allowed && !strings.Contains(diagnosticsString(diags), "From Domain Not Allowed") {
+				t.Fatalf("diagnostics = %s, want From Domain Not Allowed", diagnosticsString(diags))
+			}
+			if !tc.allowed && len(mock.templates) != 0 {
+				t.Errorf("templates = %d, want none created", len(mock.templates))
+			}
+		})
+	}
+}
+
+func TestAllowedFromDomains_InvalidFile(t *testing.T) {
+	cases := map[string]string{
+		"missing":   filepath.Join(t.TempDir(), "missing.txt"),
+		"empty":     writeAllowedFromDomains(t, "# nothing yet\n\n"),
+		"addresses": writeAllowedFromDomains(t, "example.com\nsender@example.com\n"),
+	}
+	for name, file := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := configureTestHarness(t, newMockInfobip(t), map[string]tftypes.Value{"allowed_from_domains_file": tfString(file)})
+			if !strings.Contains(diagnosticsString(diags), "Invalid allowed from domains file") {
+				t.Errorf("diagnostics = %s, want Invalid allowed from domains file", diagnosticsString(diags))
+			}
+		})
+	}
+}
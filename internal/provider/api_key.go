@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"strings"
+)
+
+// normalizeAPIKey corrects the common ways an Infobip API key is pasted
+// wrongly: surrounding whitespace or quotes, and the "App " prefix of the
+// Authorization header, which the provider adds itself. It returns the
+// corrected key and a description of every correction. Forms that cannot be
+// corrected, such as a bearer token or a JWT, are errors.
+func normalizeAPIKey(raw string) (string, []string, error) {
+	var corrections []string
+
+	key := strings.TrimSpace(raw)
+	if key != raw {
+		corrections = append(corrections, "removed surrounding whitespace")
+	}
+	for len(key) >= 2 && (key[0] == '"' || key[0] == '\'') && key[len(key)-1] == key[0] {
+		key = strings.TrimSpace(key[1 : len(key)-1])
+		corrections = append(corrections, "removed surrounding quotes")
+	}
+	if prefix, rest, ok := strings.Cut(key, " "); ok && strings.EqualFold(prefix, "App") {
+		key = strings.TrimSpace(rest)
+		corrections = append(corrections, `removed the "App " prefix, which the provider adds itself`)
+	}
+
+	switch {
+	case key == "":
+		return "", corrections, errors.New("the API key is empty")
+	case len(key) > 7 && strings.EqualFold(key[:7], "Bearer "):
+		return "", corrections, errors.New(`the API key is a bearer token; set api_key to an Infobip API key, which is sent as "App <api_key>"`)
+	case strings.HasPrefix(key, "eyJ") && strings.Count(key, ".") == 2:
+		return "", corrections, errors.New(`the API key looks like a JWT; set api_key to an Infobip API key, which is sent as "App <api_key>"`)
+	case strings.ContainsAny(key, " \t\r\n\"'"):
+		return "", corrections, errors.New("the API key contains whitespace or quotes; set api_key to the key alone, as shown in the Infobip portal")
+	}
+
+	return key, corrections, nil
+}
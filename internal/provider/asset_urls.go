@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// assetURLAttribute matches quoted src, href and background attribute values.
+// The groups are the attribute up to the opening quote, the value of a double
+// quoted attribute and the value of a single quoted one.
+var assetURLAttribute = regexp.MustCompile(`(?i)(\s(?:src|href|background)\s*=\s*)(?:"([^"]*)"|'([^']*)')`)
+
+// parseAssetBaseURL parses the asset_base_url of a template, which must be
+// an absolute http or https URL.
+func parseAssetBaseURL(raw string) (*url.URL, error) {
+	base, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute http or https URL", raw)
+	}
+
+	return base, nil
+}
+
+// rewriteAssetURLs resolves the relative URLs of the src, href and background
+// attributes of html against base. Absolute and protocol-relative URLs,
+// URLs with any scheme such as mailto:, tel: or data:, anchors and values
+// with placeholders such as {{unsubscribe_url}} are kept.
+func rewriteAssetURLs(html string, base *url.URL) string {
+	return assetURLAttribute.ReplaceAllStringFunc(html, func(attr string) string {
+		match := assetURLAttribute.FindStringSubmatch(attr)
+		value, quote := match[2], `"`
+		if strings.HasPrefix(attr[len(match[1]):], "'") {
+			value, quote = match[3], "'"
+		}
+
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") || strings.Contains(trimmed, "{{") {
+			return attr
+		}
+		ref, err := url.Parse(trimmed)
+		if err != nil || ref.Scheme != "" {
+			return attr
+		}
+
+		return match[1] + quote + base.ResolveReference(ref).String() + quote
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRewriteAssetURLs(t *testing.T) {
+	base, err := parseAssetBaseURL("https://cdn.example.com/emails/spring/")
+	if err != nil {
+		t.Fatalf("parseAssetBaseURL: %s", err)
+	}
+
+	cases := map[string]struct {
+		html string
+		want string
+	}{
+		"relative image": {
+			html: `<img src="images/logo.png" alt="Logo">`,
+			want: `<img src="https://cdn.example.com/emails/spring/images/logo.png" alt="Logo">`,
+		},
+		"root-relative link, single quotes": {
+			html: `<a href='/sale?utm=email'>Sale</a>`,
+			want: `<a href='https://cdn.example.com/sale?utm=email'>Sale</a>`,
+		},
+		"parent directory background": {
+			html: `<td background="../bg.png">`,
+			want: `<td background="https://cdn.example.com/emails/bg.png">`,
+		},
+		"absolute": {
+			html: `<img src="https://other.example.com/a.png"><a HREF="http://example.com">x</a>`,
+			want: `<img src="https://other.example.com/a.png"><a HREF="http://example.com">x</a>`,
+		},
+		"protocol-relative": {
+			html: `<img src="//cdn.example.com/a.png">`,
+			want: `<img src="//cdn.example.com/a.png">`,
+		},
+		"mailto, tel and data": {
+			html: `<a href="mailto:help@example.com">a</a><a href="tel:+123">b</a><img src="data:image/png;base64,AAAA">`,
+			want: `<a href="mailto:help@example.com">a</a><a href="tel:+123">b</a><img src="data:image/png;base64,AAAA">`,
+		},
+		"anchor and empty": {
+			html: `<a href="#top">Top</a><a href="">Empty</a>`,
+			want: `<a href="#top">Top</a><a href="">Empty</a>`,
+		},
+		"placeholder": {
+			html: `<a href="{{unsubscribe_url}}">Unsubscribe</a>`,
+			want: `<a href="{{unsubscribe_url}}">Unsubscribe</a>`,
+		},
+		"mixed": {
+			html: `<a href="#top">Top</a><img src="hero.jpg"><a href="mailto:a@example.com">Mail</a><a href="terms.html">Terms</a>`,
+			want: `<a href="#top">Top</a><img src="https://cdn.example.com/emails/spring/hero.jpg"><a href="mailto:a@example.com">Mail</a>` +
+				`<a href="https://cdn.example.com/emails/spring/terms.html">Terms</a>`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := rewriteAssetURLs(tc.html, base); got != tc.want {
+				t.Errorf("rewriteAssetURLs() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_AssetBaseURL(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"asset_base_url": tfString("https://cdn.example.com/emails/"),
+		"html":           tfString(`<html><body><img src="logo.png"><a href="#top">Top</a></body></html>`),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	want := `<html><body><img src="https://cdn.example.com/emails/logo.png"><a href="#top">Top</a></body></html>`
+	if got := mock.lastForm()["html"]; got != want {
+		t.Errorf("sent html = %q, want %q", got, want)
+	}
+	if got := r.stringAttr("html_raw"); got != want {
+		t.Errorf("html_raw = %q, want %q", got, want)
+	}
+
+	// Applying the same configuration plans no html change.
+	var prior map[string]tftypes.Value
+	_ = r.state.As(&prior)
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("re-apply: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	for _, name := range []string{"html", "html_raw"} {
+		if !planned[name].Equal(prior[name]) {
+			t.Errorf("re-apply planned %s = %s, want the prior %s", name, planned[name], prior[name])
+		}
+	}
+}
+
+func TestEmailTemplateResource_InvalidAssetBaseURL(t *testing.T) {
+	h := newTestHarness(t, newMockInfobip(t), nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.validate(testEmailTemplateConfig(map[string]tftypes.Value{
+		"asset_base_url": tfString("cdn.example.com/emails"),
+	}))
+	if !hasError(diags) {
+		t.Errorf("diagnostics = %s, want an invalid asset_base_url error", diagnosticsString(diags))
+	}
+}
+
+func TestEmailTemplateResource_OutgoingHTMLChangeOnUpdate(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	html := `<html><body><img src="a.png"></body></html>`
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString(html)})); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	// Only the outgoing html changes, not html itself.
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"html":           tfString(html),
+		"asset_base_url": tfString("https://cdn.example.com/"),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("set asset_base_url: %s", diagnosticsString(diags))
+	}
+	rewritten := `<html><body><img src="https://cdn.example.com/a.png"></body></html>`
+	if got := mock.lastForm()["html"]; got != rewritten {
+		t.Errorf("sent html = %q, want %q", got, rewritten)
+	}
+	if got := r.stringAttr("html_raw"); got != mock.lastForm()["html"] {
+		t.Errorf("html_raw = %q, want the html as sent %q", got, mock.lastForm()["html"])
+	}
+
+	minified := newTestHarness(t, mock, map[string]tftypes.Value{"minify_html": tfBool(true)}).resource("pocinfobipemails_email_template")
+	minified.state = r.state
+	minified.private = r.private
+	if diags := minified.apply(config); hasError(diags) {
+		t.Fatalf("set minify_html: %s", diagnosticsString(diags))
+	}
+	want, _ := minifyHTML(rewritten)
+	if got := mock.lastForm()["html"]; got != want {
+		t.Errorf("minified sent html = %q, want %q", got, want)
+	}
+	if got := minified.stringAttr("html_raw"); got != mock.lastForm()["html"] {
+		t.Errorf("minified html_raw = %q, want the html as sent %q", got, mock.lastForm()["html"])
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// parseBaseURL splits a base_url such as "xyz.api.infobip.com" or
+// "https://XYZ.api.infobip.com/" into a lower-cased scheme and host. A
+// missing scheme defaults to https; http is only accepted when
+// allowInsecure is set, because it would send the API key in cleartext.
+func parseBaseURL(raw string, allowInsecure bool) (scheme string, host string, err error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("base_url %q is not a valid URL: %w", raw, err)
+	}
+
+	scheme = strings.ToLower(u.Scheme)
+	host = strings.ToLower(u.Host)
+
+	if host == "" {
+		return "", "", fmt.Errorf("base_url %q has no host", raw)
+	}
+	if u.Path != "" && u.Path != "/" || u.RawQuery != "" || u.User != nil {
+		return "", "", fmt.Errorf("base_url %q must only contain a scheme and host, such as https://xyz.api.infobip.com", raw)
+	}
+
+	switch scheme {
+	case "https":
+	case "http":
+		if !allowInsecure {
+			return "", "", fmt.Errorf("base_url %q uses http, which would send the API key in cleartext. "+
+				"Use https, or set allow_insecure_transport = true when targeting a local mock", raw)
+		}
+	default:
+		return "", "", fmt.Errorf("base_url %q has unsupported scheme %q; use https", raw, u.Scheme)
+	}
+
+	return scheme, host, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+)
+
+// bulkDeleteConfirmationEnv, set to a true value, confirms deletes beyond the
+// bulk delete threshold.
+const bulkDeleteConfirmationEnv = "POCINFOBIPEMAILS_CONFIRM_BULK_DELETE"
+
+// defaultBulkDeleteThreshold is the number of template deletes allowed in a
+// single apply without confirmation.
+const defaultBulkDeleteThreshold = 10
+
+// deleteGuard counts the template deletes of an apply, which runs in a single
+// provider process, and refuses those beyond threshold unless they are
+// confirmed. A nil guard allows every delete. The destroy half of a
+// replacement is counted too, as Delete cannot tell it from other deletes.
+type deleteGuard struct {
+	threshold int
+	confirmed bool
+
+	mu    sync.Mutex
+	count int
+}
+
+// allow reports an error when one more delete would exceed the threshold
+// without confirmation, and counts the delete otherwise.
+func (g *deleteGuard) allow() error {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.count >= g.threshold && !g.confirmed {
+		return fmt.Errorf("more than %d email templates are being deleted in this apply, counting replaced ones; "+
+			"review the plan and set %s=true to confirm the bulk delete", g.threshold, bulkDeleteConfirmationEnv)
+	}
+	g.count++
+
+	return nil
+}
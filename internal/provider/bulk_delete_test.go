@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_BulkDeleteConfirmation(t *testing.T) {
+	cases := map[string]struct {
+		confirm     string
+		wantDeleted int
+	}{
+		"unconfirmed": {wantDeleted: 2},
+		"confirmed":   {confirm: "true", wantDeleted: 3},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(bulkDeleteConfirmationEnv, tc.confirm)
+
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, map[string]tftypes.Value{
+				"require_bulk_delete_confirmation": tfBool(true),
+				"bulk_delete_threshold":            tftypes.NewValue(tftypes.Number, 2),
+			})
+
+			var resources []*testResource
+			for i := range 3 {
+				r := h.resource("pocinfobipemails_email_template")
+				config := testEmailTemplateConfig(map[string]tftypes.Value{"name": tfString(fmt.Sprintf("template-%d", i))})
+				if diags := r.apply(config); hasError(diags) {
+					t.Fatalf("create %d: %s", i, diagnosticsString(diags))
+				}
+				resources = append(resources, r)
+			}
+
+			deleted := 0
+			for i, r := range resources {
+				diags := r.destroy()
+				if !hasError(diags) {
+					deleted++
+					continue
+				}
+				if !strings.Contains(diagnosticsString(diags), "Bulk Delete Not Confirmed") {
+					t.Errorf("destroy %d: %s, want a bulk delete error", i, diagnosticsString(diags))
+				}
+			}
+
+			if deleted != tc.wantDeleted {
+				t.Errorf("deleted %d templates, want %d", deleted, tc.wantDeleted)
+			}
+			if got := len(mock.templates); got != 3-tc.wantDeleted {
+				t.Errorf("%d templates remain, want %d", got, 3-tc.wantDeleted)
+			}
+		})
+	}
+}
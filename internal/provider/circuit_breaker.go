@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCircuitBreakerWindow is the time within which
+	// circuit_breaker_threshold consecutive failures open the circuit.
+	defaultCircuitBreakerWindow = time.Minute
+	// defaultCircuitBreakerCooldown is how long an open circuit fails calls
+	// before it closes again.
+	defaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// circuitBreaker stops Infobip API calls for a cooldown once threshold
+// consecutive calls have failed within window, so an Infobip outage fails
+// the remaining resources fast instead of retrying each of them. A call
+// fails with a network error or a 5xx response, after its retries. Any
+// other response closes the circuit and resets the count. It is shared by
+// the resources and data sources of a provider configuration.
+type circuitBreaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	// now returns the current time. Tests replace it.
+	now func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	// first is the time of the first of the consecutive failures.
+	first time.Time
+	// openUntil is the end of the cooldown of an open circuit.
+	openUntil time.Time
+	// lastErr describes the failure that opened the circuit.
+	lastErr string
+}
+
+func newCircuitBreaker(threshold int, window, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, window: window, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns an error while the circuit is open. Once the cooldown has
+// passed, the circuit closes and the failures are counted afresh.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return nil
+	}
+	if !b.now().Before(b.openUntil) {
+		b.openUntil = time.Time{}
+		b.failures = 0
+		return nil
+	}
+
+	return fmt.Errorf("circuit open: %d consecutive Infobip API calls failed within %s, the last with %s, "+
+		"so no calls are made until %s", b.threshold, b.window, b.lastErr, b.openUntil.Format(time.RFC3339))
+}
+
+// record counts the outcome of a call, opening the circuit on the
+// threshold-th consecutive failure within the window.
+func (b *circuitBreaker) record(failure string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if failure == "" {
+		b.failures = 0
+		return
+	}
+
+	now := b.now()
+	if b.failures == 0 || now.Sub(b.first) > b.window {
+		b.failures = 0
+		b.first = now
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+		b.lastErr = failure
+	}
+}
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &circuitBreakerTransport{}
+
+// circuitBreakerTransport fails requests while breaker is open, without
+// sending them, and records the outcome of the others.
+type circuitBreakerTransport struct {
+	next    http.RoundTripper
+	breaker *circuitBreaker
+}
+
+func newCircuitBreakerTransport(next http.RoundTripper, breaker *circuitBreaker) *circuitBreakerTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &circuitBreakerTransport{next: next, breaker: breaker}
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.allow(); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil:
+		// A cancelled request says nothing about Infobip.
+		if req.Context().Err() == nil {
+			t.breaker.record(err.Error())
+		}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		t.breaker.record(resp.Status)
+	default:
+		t.breaker.record("")
+	}
+
+	return resp, err
+}
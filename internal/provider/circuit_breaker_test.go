@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(3, time.Minute, 30*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	status := http.StatusServiceUnavailable
+	calls := 0
+	transport := newCircuitBreakerTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: http.NoBody}, nil
+	}), breaker)
+
+	roundTrip := func() error {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, "https://api.infobip.com/email/1/templates/1", nil)
+		_, err := transport.RoundTrip(req)
+		return err
+	}
+
+	// Failures spread over more than the window do not open the circuit.
+	for i := 0; i < 3; i++ {
+		if err := roundTrip(); err != nil {
+			t.Fatalf("round trip %d: %s", i, err)
+		}
+		now = now.Add(40 * time.Second)
+	}
+
+	// A success resets the count.
+	for _, s := range []int{http.StatusOK, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK, http.StatusBadGateway, http.StatusBadGateway} {
+		status = s
+		if err := roundTrip(); err != nil {
+			t.Fatalf("round trip with %d: %s", s, err)
+		}
+	}
+
+	// The third consecutive failure within the window opens the circuit.
+	if err := roundTrip(); err != nil {
+		t.Fatalf("opening round trip: %s", err)
+	}
+	calls = 0
+	err := roundTrip()
+	if err == nil || !strings.Contains(err.Error(), "circuit open") {
+		t.Fatalf("error = %v, want circuit open", err)
+	}
+	if calls != 0 {
+		t.Errorf("round trips while open = %d, want 0", calls)
+	}
+
+	// Once the cooldown has passed, calls are sent again.
+	now = now.Add(31 * time.Second)
+	status = http.StatusOK
+	if err := roundTrip(); err != nil {
+		t.Fatalf("round trip after the cooldown: %s", err)
+	}
+	if calls != 1 {
+		t.Errorf("round trips after the cooldown = %d, want 1", calls)
+	}
+}
+
+func TestEmailTemplateResource_CircuitBreaker(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, map[string]tftypes.Value{
+		"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, 2),
+		"circuit_breaker_cooldown":  tfString("1h"),
+	})
+	r := h.resource("pocinfobipemails_email_template")
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	mock.mu.Lock()
+	mock.failGet[1] = true
+	mock.mu.Unlock()
+	for i := 0; i < 2; i++ {
+		if diags := r.refresh(); !hasError(diags) {
+			t.Fatalf("refresh %d succeeded, want the 500", i)
+		}
+	}
+
+	gets := countRequests(mock, "GET /email/1/templates/1")
+	diags := r.refresh()
+	if !strings.Contains(diagnosticsString(diags), "circuit open") {
+		t.Errorf("diagnostics = %s, want circuit open", diagnosticsString(diags))
+	}
+	if got := countRequests(mock, "GET /email/1/templates/1"); got != gets {
+		t.Errorf("reads while open = %d, want 0", got-gets)
+	}
+}
+
+func TestProvider_InvalidCircuitBreaker(t *testing.T) {
+	cases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		want           string
+	}{
+		"threshold": {
+			providerConfig: map[string]tftypes.Value{"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, 0)},
+			want:           "Invalid circuit breaker threshold",
+		},
+		"window": {
+			providerConfig: map[string]tftypes.Value{
+				"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, 5),
+				"circuit_breaker_window":    tfString("soon"),
+			},
+			want: "Invalid circuit breaker duration",
+		},
+		"cooldown": {
+			providerConfig: map[string]tftypes.Value{
+				"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, 5),
+				"circuit_breaker_cooldown":  tfString("-1m"),
+			},
+			want: "Invalid circuit breaker duration",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := configureTestHarness(t, newMockInfobip(t), tc.providerConfig)
+			if !strings.Contains(diagnosticsString(diags), tc.want) {
+				t.Errorf("diagnostics = %s, want %q", diagnosticsString(diags), tc.want)
+			}
+		})
+	}
+}
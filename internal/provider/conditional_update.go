@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ifMatchHeader makes an update conditional on the ETag of the template.
+const ifMatchHeader = "If-Match"
+
+// privateKeyAPIUpdatedAt is the private state key holding the updated_at
+// Infobip reported the last time the provider read or wrote the template.
+// Updates compare it against the current value when Infobip sends no ETag.
+const privateKeyAPIUpdatedAt = "api_updated_at"
+
+// ifMatchContextKey is the context key of the ETag set by withIfMatch.
+type ifMatchContextKey struct{}
+
+// withIfMatch returns ctx with an ETag that requests made with it must
+// match. The Infobip client has no per-request headers, so the ETag travels
+// in the context like the organization ID.
+func withIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchContextKey{}, etag)
+}
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &ifMatchTransport{}
+
+// ifMatchTransport sets ifMatchHeader on requests whose context carries an
+// ETag.
+type ifMatchTransport struct {
+	next http.RoundTripper
+}
+
+func newIfMatchTransport(next http.RoundTripper) *ifMatchTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &ifMatchTransport{next: next}
+}
+
+func (t *ifMatchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	etag, _ := req.Context().Value(ifMatchContextKey{}).(string)
+	if etag == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the request.
+	req = req.Clone(req.Context())
+	req.Header.Set(ifMatchHeader, etag)
+
+	return t.next.RoundTrip(req)
+}
+
+// responseETag returns the ETag of resp, or null when there is none.
+func responseETag(resp *http.Response) types.String {
+	if resp == nil || resp.Header.Get("ETag") == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(resp.Header.Get("ETag"))
+}
+
+// isPreconditionFailed reports whether resp rejected a conditional update.
+func isPreconditionFailed(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusPreconditionFailed
+}
+
+// addConcurrentUpdateError reports that the template with id changed since
+// it was last read, so updating it would overwrite those changes.
+func addConcurrentUpdateError(diags *diag.Diagnostics, id types.String) {
+	diags.AddError(
+		"Email Template Changed Concurrently",
+		"Email template ID "+id.String()+" was changed, such as in the Infobip UI, since Terraform last read it, "+
+			"so the update was not applied to avoid overwriting those changes. Run terraform apply again to refresh the template and review the plan.",
+	)
+}
+
+// apiUpdatedAtValue returns the private state value for
+// privateKeyAPIUpdatedAt.
+func apiUpdatedAtValue(updatedAt string) []byte {
+	value, _ := json.Marshal(updatedAt)
+
+	return value
+}
+
+// apiUpdatedAtFromPrivate decodes a privateKeyAPIUpdatedAt value. It
+// returns "" when the value is missing.
+func apiUpdatedAtFromPrivate(value []byte) string {
+	var updatedAt string
+	if len(value) == 0 || json.Unmarshal(value, &updatedAt) != nil {
+		return ""
+	}
+
+	return updatedAt
+}
+
+// updatedSince reports whether current, the updated_at of a template as
+// reported now, differs from known, the value last seen. Either being empty
+// means the change cannot be detected.
+func updatedSince(known, current string) bool {
+	if known == "" || current == "" {
+		return false
+	}
+
+	knownTime, knownOK := parseTemplateTimestamp(known)
+	currentTime, currentOK := parseTemplateTimestamp(current)
+	if knownOK && currentOK {
+		return !knownTime.Equal(currentTime)
+	}
+
+	return known != current
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmailTemplateResource_ConcurrentEdit(t *testing.T) {
+	cases := map[string]struct {
+		etags bool
+	}{
+		"etag":       {etags: true},
+		"updated_at": {},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			mock.etags = tc.etags
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(nil)
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+			id := mustParseID(t, r.stringAttr("id"))
+			mock.mu.Lock()
+			mock.templates[id].UpdatedAt = "2026-01-02T03:04:05Z"
+			mock.mu.Unlock()
+			if diags := r.refresh(); hasError(diags) {
+				t.Fatalf("refresh: %s", diagnosticsString(diags))
+			}
+			if got := r.attr("etag").IsNull(); got == tc.etags {
+				t.Errorf("etag = %s, want it set %t", r.attr("etag"), tc.etags)
+			}
+
+			// The template is edited in the UI after the refresh.
+			mock.mu.Lock()
+			mock.templates[id].Subject = "Edited in the UI"
+			mock.templates[id].UpdatedAt = "2026-01-02T04:00:00Z"
+			mock.mu.Unlock()
+
+			config["html"] = tfString("<html><body>Changed</body></html>")
+			diags := r.apply(config)
+			if !strings.Contains(diagnosticsString(diags), "Email Template Changed Concurrently") {
+				t.Fatalf("diagnostics = %s, want Email Template Changed Concurrently", diagnosticsString(diags))
+			}
+			mock.mu.Lock()
+			subject, html := mock.templates[id].Subject, mock.templates[id].HTML
+			mock.mu.Unlock()
+			if subject != "Edited in the UI" || strings.Contains(html, "Changed") {
+				t.Errorf("template subject %q, html %q, want the UI edit kept", subject, html)
+			}
+
+			// Once refreshed, the update goes through.
+			if diags := r.refresh(); hasError(diags) {
+				t.Fatalf("refresh: %s", diagnosticsString(diags))
+			}
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+			if !strings.Contains(mock.lastForm()["html"], "Changed") {
+				t.Errorf("html = %q, want the update applied", mock.lastForm()["html"])
+			}
+		})
+	}
+}
+
+func TestUpdatedSince(t *testing.T) {
+	cases := map[string]struct {
+		known, current string
+		want           bool
+	}{
+		"unchanged":        {known: "2026-01-02T03:04:05Z", current: "2026-01-02T03:04:05Z"},
+		"changed":          {known: "2026-01-02T03:04:05Z", current: "2026-01-02T03:04:06Z", want: true},
+		"same instant":     {known: "2026-01-02T03:04:05Z", current: "2026-01-02T04:04:05+01:00"},
+		"legacy format":    {known: "Friday, 02-Jan-26 03:04:05 UTC", current: "2026-01-02T03:04:05Z"},
+		"unparsed changed": {known: "rev-1", current: "rev-2", want: true},
+		"never read":       {current: "2026-01-02T03:04:05Z"},
+		"not reported":     {known: "2026-01-02T03:04:05Z"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := updatedSince(tc.known, tc.current); got != tc.want {
+				t.Errorf("updatedSince(%q, %q) = %t, want %t", tc.known, tc.current, got, tc.want)
+			}
+		})
+	}
+}
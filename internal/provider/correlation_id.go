@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// correlationIDHeader carries the correlation ID of the provider run on every
+// request, so Infobip logs can be matched with provider logs.
+const correlationIDHeader = "X-Correlation-Id"
+
+// correlationIDLogField is the log field carrying the correlation ID.
+const correlationIDLogField = "correlation_id"
+
+// correlateLogs returns a child of ctx whose log lines carry the correlation
+// ID id. Like redactLogs, it is bound to the context, so every method that
+// logs must derive its context from it.
+func correlateLogs(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+
+	return tflog.SetField(ctx, correlationIDLogField, id)
+}
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &correlationTransport{}
+
+// correlationTransport sets correlationIDHeader on every request.
+type correlationTransport struct {
+	next http.RoundTripper
+	id   string
+}
+
+func newCorrelationTransport(next http.RoundTripper, id string) *correlationTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &correlationTransport{next: next, id: id}
+}
+
+func (t *correlationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.id == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the request.
+	req = req.Clone(req.Context())
+	req.Header.Set(correlationIDHeader, t.id)
+
+	return t.next.RoundTrip(req)
+}
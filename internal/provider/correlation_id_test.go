@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestEmailTemplateResource_CorrelationID(t *testing.T) {
+	mock := newMockInfobip(t)
+	var headers []string
+	mock.onRequest = func(r *http.Request) {
+		headers = append(headers, r.Header.Get(correlationIDHeader))
+	}
+	h := newTestHarness(t, mock, nil)
+	var output bytes.Buffer
+	h.ctx = tflogtest.RootLogger(context.Background(), &output)
+	r := h.resource("pocinfobipemails_email_template")
+
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	// Every request of the run, including the one made by Configure,
+	// carries the same ID.
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(headers) < 2 || headers[0] == "" {
+		t.Fatalf("%s headers = %q, want one per request", correlationIDHeader, headers)
+	}
+	id := headers[0]
+	for i, header := range headers {
+		if header != id {
+			t.Errorf("request %d %s = %q, want %q", i, correlationIDHeader, header, id)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decoding logs: %s", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("expected the create to log")
+	}
+	for _, entry := range entries {
+		if entry[correlationIDLogField] != id {
+			t.Errorf("log line %q %s = %v, want %q", entry["@message"], correlationIDLogField, entry[correlationIDLogField], id)
+		}
+	}
+
+	// Another run gets another ID.
+	other := newMockInfobip(t)
+	var otherID string
+	other.onRequest = func(r *http.Request) {
+		otherID = r.Header.Get(correlationIDHeader)
+	}
+	newTestHarness(t, other, nil)
+	if otherID == "" || otherID == id {
+		t.Errorf("second run %s = %q, want a new ID", correlationIDHeader, otherID)
+	}
+}
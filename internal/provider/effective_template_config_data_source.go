@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EffectiveTemplateConfigDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &EffectiveTemplateConfigDataSource{}
+
+func NewEffectiveTemplateConfigDataSource() datasource.DataSource {
+	return &EffectiveTemplateConfigDataSource{}
+}
+
+// EffectiveTemplateConfigDataSource resolves template attributes the way
+// the email template resource would send them, without calling the Infobip
+// API.
+type EffectiveTemplateConfigDataSource struct {
+	// minifyHTML minifies html before it is sent.
+	minifyHTML bool
+	// whitespaceOnlyAsNull treats a whitespace-only subject or preheader as
+	// empty.
+	whitespaceOnlyAsNull bool
+	// placeholderSyntax matches the placeholders of the html.
+	placeholderSyntax *regexp.Regexp
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+}
+
+// EffectiveTemplateConfigDataSourceModel describes the data source data model.
+type EffectiveTemplateConfigDataSourceModel struct {
+	Subject            types.String `tfsdk:"subject"`
+	Preheader          types.String `tfsdk:"preheader"`
+	PreheaderSegments  types.List   `tfsdk:"preheader_segments"`
+	Html               types.String `tfsdk:"html"`
+	AssetBaseUrl       types.String `tfsdk:"asset_base_url"`
+	SendNormalizedHtml types.Bool   `tfsdk:"send_normalized_html"`
+	SubjectNormalize   types.Bool   `tfsdk:"subject_normalize"`
+	EffectiveSubject   types.String `tfsdk:"effective_subject"`
+	EffectivePreheader types.String `tfsdk:"effective_preheader"`
+	EffectiveHtml      types.String `tfsdk:"effective_html"`
+	Placeholders       types.Set    `tfsdk:"placeholders"`
+	HtmlSummary        types.String `tfsdk:"html_summary"`
+}
+
+func (d *EffectiveTemplateConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_template_config"
+}
+
+func (d *EffectiveTemplateConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resolves the subject, preheader and html of an email template as `pocinfobipemails_email_template` would send them, " +
+			"after applying the provider's `minify_html` and `whitespace_only_as_null` settings and the template's normalization attributes, " +
+			"without calling the Infobip API. Attributes that are not listed here, such as `from` and `reply_to`, are sent as configured. " +
+			"Remote images are not inlined and `parent_id` is not composed, since both need network access.",
+		Attributes: map[string]schema.Attribute{
+			"subject": schema.StringAttribute{
+				Description: "Subject line, as in `pocinfobipemails_email_template`. Must not be empty.",
+				Required:    true,
+				Validators: []validator.String{
+					emptySubjectValidator{},
+				},
+			},
+			"preheader": schema.StringAttribute{
+				Description: "Preheader text, as in `pocinfobipemails_email_template`.",
+				Optional:    true,
+			},
+			"preheader_segments": schema.ListAttribute{
+				Description: "Preheader split into segments, as an alternative to `preheader`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"html": schema.StringAttribute{
+				Description: "HTML content, as in `pocinfobipemails_email_template`.",
+				Required:    true,
+				Validators: []validator.String{
+					htmlControlCharactersValidator{},
+				},
+			},
+			"asset_base_url": schema.StringAttribute{
+				Description: "Absolute http or https URL that relative URLs in the html are resolved against, as in `pocinfobipemails_email_template`.",
+				Optional:    true,
+			},
+			"send_normalized_html": schema.BoolAttribute{
+				Description: "Normalize the html before it is sent, as in `pocinfobipemails_email_template`. Defaults to `false`.",
+				Optional:    true,
+			},
+			"subject_normalize": schema.BoolAttribute{
+				Description: "Trim the subject and collapse repeated whitespace in it, as in `pocinfobipemails_email_template`. Defaults to `false`.",
+				Optional:    true,
+			},
+			"effective_subject": schema.StringAttribute{
+				Description: "The subject as it would be sent.",
+				Computed:    true,
+			},
+			"effective_preheader": schema.StringAttribute{
+				Description: "The preheader as it would be sent: `preheader`, or the joined `preheader_segments`, empty when whitespace-only and the provider sets `whitespace_only_as_null`.",
+				Computed:    true,
+			},
+			"effective_html": schema.StringAttribute{
+				Description: "The html as it would be sent, with `asset_base_url`, `send_normalized_html` and the provider's `minify_html` applied.",
+				Computed:    true,
+			},
+			"placeholders": schema.SetAttribute{
+				Description: "Names of the merge placeholders used in the html, such as `first_name` for `{{first_name}}`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"html_summary": schema.StringAttribute{
+				Description: "Line count, byte count and shortened sha256 digest of `effective_html`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *EffectiveTemplateConfigDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data EffectiveTemplateConfigDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Preheader.IsNull() && !data.PreheaderSegments.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preheader_segments"),
+			"Conflicting Preheader Attributes",
+			"Only one of preheader and preheader_segments can be set.",
+		)
+	}
+
+	if !data.AssetBaseUrl.IsNull() && !data.AssetBaseUrl.IsUnknown() {
+		if _, err := parseAssetBaseURL(data.AssetBaseUrl.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("asset_base_url"),
+				"Invalid Asset Base URL",
+				"asset_base_url must be an absolute http or https URL: "+err.Error(),
+			)
+		}
+	}
+}
+
+func (d *EffectiveTemplateConfigDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.minifyHTML = pd.minifyHTML
+	d.whitespaceOnlyAsNull = pd.whitespaceOnlyAsNull
+	d.placeholderSyntax = pd.placeholderSyntax
+	d.logRedactPatterns = pd.logRedactPatterns
+	d.correlationID = pd.correlationID
+	d.warningsAsErrors = pd.warningsAsErrors
+}
+
+func (d *EffectiveTemplateConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = redactLogs(ctx, d.logRedactPatterns)
+	ctx = correlateLogs(ctx, d.correlationID)
+	defer escalateWarnings(d.warningsAsErrors, &resp.Diagnostics)
+
+	var data EffectiveTemplateConfigDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve the values with the same steps as the resource's ModifyPlan
+	// and Create, which work on the resource model.
+	template := EmailTemplateResourceModel{
+		Subject:            data.Subject,
+		Preheader:          data.Preheader,
+		PreheaderSegments:  data.PreheaderSegments,
+		Html:               data.Html,
+		AssetBaseUrl:       data.AssetBaseUrl,
+		SendNormalizedHtml: data.SendNormalizedHtml,
+	}
+	resp.Diagnostics.Append(checkPreheaderSegments(ctx, template)...)
+
+	if d.whitespaceOnlyAsNull {
+		if isWhitespaceOnly(template.Subject) {
+			resp.Diagnostics.Append(emptySubjectDiagnostic(path.Root("subject")))
+			return
+		}
+		if isWhitespaceOnly(template.Preheader) {
+			template.Preheader = types.StringValue("")
+		}
+	}
+
+	if data.SubjectNormalize.ValueBool() {
+		template.Subject = types.StringValue(collapseWhitespace(template.Subject.ValueString()))
+		if template.Subject.ValueString() == "" {
+			resp.Diagnostics.Append(emptySubjectDiagnostic(path.Root("subject")))
+			return
+		}
+	}
+
+	resource := EmailTemplateResource{minifyHTML: d.minifyHTML}
+	html, err := resource.outgoingHTML(template.Html.ValueString(), template)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("html"),
+			"Error Preparing HTML",
+			"Could not prepare the email template html: "+err.Error(),
+		)
+		return
+	}
+
+	data.EffectiveSubject = template.Subject
+	data.EffectivePreheader = types.StringValue(effectivePreheader(ctx, template).ValueString())
+	data.EffectiveHtml = types.StringValue(html)
+	data.Placeholders = placeholdersValue(normalizeHTML(template.Html.ValueString()), d.placeholderSyntax)
+	data.HtmlSummary = types.StringValue(htmlSummary(html))
+
+	tflog.Trace(ctx, "read effective template config data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
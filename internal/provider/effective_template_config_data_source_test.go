@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEffectiveTemplateConfigDataSource(t *testing.T) {
+	const html = "<html>\n  <body>\n    <p>Hi {{first_name}}</p>\n    <img src=\"logo.png\">\n  </body>\n</html>\n"
+
+	rewritten := "<html>\n  <body>\n    <p>Hi {{first_name}}</p>\n    <img src=\"https://cdn.example.com/emails/logo.png\">\n  </body>\n</html>\n"
+	minified, _ := minifyHTML(rewritten)
+
+	config := map[string]tftypes.Value{
+		"subject":        tfString("  Welcome   aboard "),
+		"preheader":      tfString("   "),
+		"html":           tfString(html),
+		"asset_base_url": tfString("https://cdn.example.com/emails/"),
+	}
+
+	cases := map[string]struct {
+		providerConfig   map[string]tftypes.Value
+		subjectNormalize bool
+		want             map[string]string
+	}{
+		"provider defaults": {
+			want: map[string]string{
+				"effective_subject":   "  Welcome   aboard ",
+				"effective_preheader": "   ",
+				"effective_html":      rewritten,
+				"html_summary":        htmlSummary(rewritten),
+			},
+		},
+		"provider options": {
+			providerConfig: map[string]tftypes.Value{
+				"minify_html":             tfBool(true),
+				"whitespace_only_as_null": tfBool(true),
+			},
+			want: map[string]string{
+				"effective_subject":   "  Welcome   aboard ",
+				"effective_preheader": "",
+				"effective_html":      minified,
+				"html_summary":        htmlSummary(minified),
+			},
+		},
+		"provider options and subject_normalize": {
+			providerConfig: map[string]tftypes.Value{
+				"minify_html":             tfBool(true),
+				"whitespace_only_as_null": tfBool(true),
+			},
+			subjectNormalize: true,
+			want: map[string]string{
+				"effective_subject":   "Welcome aboard",
+				"effective_preheader": "",
+				"effective_html":      minified,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, tc.providerConfig)
+			mock.onRequest = func(r *http.Request) {
+				t.Errorf("unexpected %s %s: the data source must not call the API", r.Method, r.URL.Path)
+			}
+
+			attrs := map[string]tftypes.Value{"subject_normalize": tfBool(tc.subjectNormalize)}
+			for attr, value := range config {
+				attrs[attr] = value
+			}
+			state, diags := h.readDataSource("pocinfobipemails_effective_template_config", attrs)
+			if hasError(diags) {
+				t.Fatalf("read: %s", diagnosticsString(diags))
+			}
+
+			var got map[string]tftypes.Value
+			_ = state.As(&got)
+			for attr, value := range tc.want {
+				if !got[attr].Equal(tfString(value)) {
+					t.Errorf("%s = %s, want %q", attr, got[attr], value)
+				}
+			}
+
+			want := tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tfString("first_name")})
+			if !got["placeholders"].Equal(want) {
+				t.Errorf("placeholders = %s, want [first_name]", got["placeholders"])
+			}
+		})
+	}
+}
+
+func TestEffectiveTemplateConfigDataSource_WhitespaceOnlySubject(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, map[string]tftypes.Value{"whitespace_only_as_null": tfBool(true)})
+
+	_, diags := h.readDataSource("pocinfobipemails_effective_template_config", map[string]tftypes.Value{
+		"subject": tfString("   "),
+		"html":    tfString("<p>Hi</p>"),
+	})
+	if !strings.Contains(diagnosticsString(diags), "Empty Email Template Subject") {
+		t.Errorf("diagnostics = %s, want an empty subject error", diagnosticsString(diags))
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmailDriftDataSource{}
+
+func NewEmailDriftDataSource() datasource.DataSource {
+	return &EmailDriftDataSource{}
+}
+
+// EmailDriftDataSource compares the html of live templates with local html
+// files, to find templates edited outside of their source files.
+type EmailDriftDataSource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+}
+
+// EmailDriftDataSourceModel describes the data source data model.
+type EmailDriftDataSourceModel struct {
+	Files   map[string]string `tfsdk:"files"`
+	Drifted []string          `tfsdk:"drifted"`
+	Missing []string          `tfsdk:"missing"`
+}
+
+func (d *EmailDriftDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_drift"
+}
+
+func (d *EmailDriftDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Compares the html of live Infobip Email Templates with local html files, such as the sources kept in a design repository. " +
+			"The html is compared the way the `pocinfobipemails_email_template` resource compares it, so whitespace-only differences are not drift.",
+		Attributes: map[string]schema.Attribute{
+			"files": schema.MapAttribute{
+				Description: "Paths of the source html files, keyed by the name of the template they are the source of.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"drifted": schema.ListAttribute{
+				Description: "Sorted names of the templates whose live html differs from their file.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"missing": schema.ListAttribute{
+				Description: "Sorted names in `files` that no live template has.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *EmailDriftDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.infobipClient = pd.client
+	d.apiKey = pd.apiKey
+	d.authSchemeKey = pd.authSchemeKey
+	d.logRedactPatterns = pd.logRedactPatterns
+	d.correlationID = pd.correlationID
+	d.organizationID = pd.organizationID
+	d.warningsAsErrors = pd.warningsAsErrors
+	d.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+}
+
+func (d *EmailDriftDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = redactLogs(ctx, d.logRedactPatterns)
+	ctx = correlateLogs(ctx, d.correlationID)
+	ctx = collectRateLimits(ctx, d.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(d.warningsAsErrors, &resp.Diagnostics)
+
+	var data EmailDriftDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := listEmailTemplates(infobipAuthContext(ctx, d.apiKey, d.authSchemeKey, d.organizationID), d.infobipClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Email Templates",
+			"An error was encountered while listing email templates: "+err.Error(),
+		)
+		return
+	}
+
+	// The list includes the html, so every template is compared without a
+	// request of its own.
+	live := map[string][]string{}
+	for _, item := range items {
+		live[item.GetName()] = append(live[item.GetName()], item.GetBody())
+	}
+
+	names := make([]string, 0, len(data.Files))
+	for name := range data.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	data.Drifted = []string{}
+	data.Missing = []string{}
+	for _, name := range names {
+		source, err := os.ReadFile(data.Files[name])
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("files").AtMapKey(name),
+				"Error Reading HTML File",
+				fmt.Sprintf("Could not read the html file of template %q: %s", name, err),
+			)
+			continue
+		}
+
+		switch bodies := live[name]; len(bodies) {
+		case 0:
+			data.Missing = append(data.Missing, name)
+		case 1:
+			if normalizeHTML(bodies[0]) != normalizeHTML(string(source)) {
+				data.Drifted = append(data.Drifted, name)
+			}
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("files").AtMapKey(name),
+				"Ambiguous Email Template Name",
+				fmt.Sprintf("%d email templates are named %q; rename them so the file can be compared with a single template.", len(bodies), name),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "compared email templates with html files", map[string]any{"drifted": len(data.Drifted), "missing": len(data.Missing)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
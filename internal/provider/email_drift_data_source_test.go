@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func driftFiles(t *testing.T, files map[string]string) tftypes.Value {
+	t.Helper()
+
+	dir := t.TempDir()
+	paths := map[string]tftypes.Value{}
+	for name, content := range files {
+		path := filepath.Join(dir, name+".html")
+		if content != "" {
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatalf("writing %s: %s", path, err)
+			}
+		}
+		paths[name] = tfString(path)
+	}
+
+	return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, paths)
+}
+
+func TestEmailDriftDataSource(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "welcome", Subject: "Hi", HTML: "<html><body><h1>Welcome</h1></body></html>"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "reset", Subject: "Reset", HTML: "<html><body><p>Edited in the UI</p></body></html>"})
+	h := newTestHarness(t, mock, nil)
+
+	state, diags := h.readDataSource("pocinfobipemails_email_drift", map[string]tftypes.Value{
+		"files": driftFiles(t, map[string]string{
+			// Only differs by whitespace, so it matches.
+			"welcome": "<html>\n  <body>\n    <h1>Welcome</h1>\n  </body>\n</html>\n",
+			"reset":   "<html><body><p>Reset your password</p></body></html>",
+			"launch":  "<html><body><p>Coming soon</p></body></html>",
+		}),
+	})
+	if hasError(diags) {
+		t.Fatalf("read: %s", diagnosticsString(diags))
+	}
+
+	var attrs map[string]tftypes.Value
+	_ = state.As(&attrs)
+	for name, want := range map[string][]string{"drifted": {"reset"}, "missing": {"launch"}} {
+		var values []tftypes.Value
+		_ = attrs[name].As(&values)
+		var got []string
+		for _, v := range values {
+			var s string
+			_ = v.As(&s)
+			got = append(got, s)
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestEmailDriftDataSource_Errors(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "welcome", Subject: "Hi", HTML: "<p>One</p>"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "welcome", Subject: "Hi", HTML: "<p>Two</p>"})
+	h := newTestHarness(t, mock, nil)
+
+	cases := map[string]struct {
+		files map[string]string
+		want  string
+	}{
+		"ambiguous name": {files: map[string]string{"welcome": "<p>One</p>"}, want: "Ambiguous Email Template Name"},
+		"missing file":   {files: map[string]string{"reset": ""}, want: "Error Reading HTML File"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := h.readDataSource("pocinfobipemails_email_drift", map[string]tftypes.Value{"files": driftFiles(t, tc.files)})
+			if !strings.Contains(diagnosticsString(diags), tc.want) {
+				t.Errorf("diagnostics = %s, want %s", diagnosticsString(diags), tc.want)
+			}
+		})
+	}
+}
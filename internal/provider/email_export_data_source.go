@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmailExportDataSource{}
+
+func NewEmailExportDataSource() datasource.DataSource {
+	return &EmailExportDataSource{}
+}
+
+// EmailExportDataSource writes the html of the account's templates to files
+// in a directory, for backups and reviews outside of Infobip.
+type EmailExportDataSource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+}
+
+// EmailExportDataSourceModel describes the data source data model.
+type EmailExportDataSourceModel struct {
+	OutputDir types.String                `tfsdk:"output_dir"`
+	IDs       []string                    `tfsdk:"ids"`
+	Files     []EmailExportDataSourceFile `tfsdk:"files"`
+}
+
+// EmailExportDataSourceFile describes a single exported template.
+type EmailExportDataSourceFile struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Path types.String `tfsdk:"path"`
+}
+
+// exportFileNameUnsafe matches the runs of characters replaced in template
+// names to build file names.
+var exportFileNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportFileNameMaxLength caps the name part of exported file names.
+const exportFileNameMaxLength = 100
+
+// exportFileName returns the file name a template is exported to:
+// "<id>-<name>.html" with the characters of the name that are unsafe in file
+// names replaced by "-". The id prefix keeps the names of templates that
+// share a name, or whose names only differ in unsafe characters, distinct.
+func exportFileName(id int64, name string) string {
+	slug := strings.Trim(exportFileNameUnsafe.ReplaceAllString(name, "-"), "-.")
+	if len(slug) > exportFileNameMaxLength {
+		slug = strings.TrimRight(slug[:exportFileNameMaxLength], "-.")
+	}
+	if slug == "" {
+		return fmt.Sprintf("%d.html", id)
+	}
+
+	return fmt.Sprintf("%d-%s.html", id, slug)
+}
+
+// writeFileIfChanged writes content to name unless the file already holds
+// it, so repeated exports leave unchanged files untouched.
+func writeFileIfChanged(name string, content []byte) error {
+	if existing, err := os.ReadFile(name); err == nil && bytes.Equal(existing, content) {
+		return nil
+	}
+
+	return os.WriteFile(name, content, 0o644)
+}
+
+func (d *EmailExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_export"
+}
+
+func (d *EmailExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports the html of Infobip Email Templates to files in `output_dir`, one `<id>-<name>.html` file per template. " +
+			"Files whose content is unchanged are not rewritten.",
+		Attributes: map[string]schema.Attribute{
+			"output_dir": schema.StringAttribute{
+				Description: "Directory the files are written to. It is created when missing.",
+				Required:    true,
+			},
+			"ids": schema.SetAttribute{
+				Description: "Ids of the templates to export, typically the `id` of every `pocinfobipemails_email_template` resource. " +
+					"Defaults to every template of the account.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"files": schema.ListNestedAttribute{
+				Description: "Exported templates, ordered by id.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the email template.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the email template.",
+							Computed:    true,
+						},
+						"path": schema.StringAttribute{
+							Description: "Path of the file the html was written to.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EmailExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.infobipClient = pd.client
+	d.apiKey = pd.apiKey
+	d.authSchemeKey = pd.authSchemeKey
+	d.logRedactPatterns = pd.logRedactPatterns
+	d.correlationID = pd.correlationID
+	d.organizationID = pd.organizationID
+	d.warningsAsErrors = pd.warningsAsErrors
+	d.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+}
+
+func (d *EmailExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = redactLogs(ctx, d.logRedactPatterns)
+	ctx = correlateLogs(ctx, d.correlationID)
+	ctx = collectRateLimits(ctx, d.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(d.warningsAsErrors, &resp.Diagnostics)
+
+	var data EmailExportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := listEmailTemplates(infobipAuthContext(ctx, d.apiKey, d.authSchemeKey, d.organizationID), d.infobipClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Email Templates",
+			"An error was encountered while listing email templates: "+err.Error(),
+		)
+		return
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].GetId() < items[j].GetId() })
+
+	var selected map[string]bool
+	if data.IDs != nil {
+		selected = make(map[string]bool, len(data.IDs))
+		for _, id := range data.IDs {
+			selected[id] = true
+		}
+	}
+
+	dir := data.OutputDir.ValueString()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_dir"),
+			"Error Creating Export Directory",
+			fmt.Sprintf("Could not create output_dir %s: %s", data.OutputDir.String(), err),
+		)
+		return
+	}
+
+	files := []EmailExportDataSourceFile{}
+	for _, item := range items {
+		id := fmt.Sprintf("%d", item.GetId())
+		if selected != nil && !selected[id] {
+			continue
+		}
+
+		name := filepath.Join(dir, exportFileName(item.GetId(), item.GetName()))
+		if err := writeFileIfChanged(name, []byte(item.GetBody())); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Exporting Email Template",
+				fmt.Sprintf("Could not write email template ID %s to %s: %s", id, name, err),
+			)
+			return
+		}
+
+		files = append(files, EmailExportDataSourceFile{
+			ID:   types.StringValue(id),
+			Name: types.StringValue(item.GetName()),
+			Path: types.StringValue(name),
+		})
+	}
+
+	data.Files = files
+	tflog.Trace(ctx, "exported email templates", map[string]any{"output_dir": dir, "count": len(files)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExportFileName(t *testing.T) {
+	cases := map[string]struct {
+		name string
+		want string
+	}{
+		"plain":     {name: "welcome", want: "7-welcome.html"},
+		"unsafe":    {name: "Reset / password: v2?", want: "7-Reset-password-v2.html"},
+		"traversal": {name: "../../etc/passwd", want: "7-etc-passwd.html"},
+		"empty":     {name: "***", want: "7.html"},
+		"long":      {name: strings.Repeat("a", 150), want: "7-" + strings.Repeat("a", exportFileNameMaxLength) + ".html"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := exportFileName(7, tc.name); got != tc.want {
+				t.Errorf("exportFileName(7, %q) = %q, want %q", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEmailExportDataSource(t *testing.T) {
+	mock := newMockInfobip(t)
+	welcomeID := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Welcome", Subject: "Hi", HTML: "<p>Welcome!</p>"})
+	// Templates that share a name are exported to distinct files.
+	otherID := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Welcome", Subject: "Hi", HTML: "<p>Other</p>"})
+	h := newTestHarness(t, mock, nil)
+
+	dir := filepath.Join(t.TempDir(), "export")
+	config := map[string]tftypes.Value{"output_dir": tfString(dir)}
+	if _, diags := h.readDataSource("pocinfobipemails_email_export", config); hasError(diags) {
+		t.Fatalf("read: %s", diagnosticsString(diags))
+	}
+
+	want := map[string]string{
+		exportFileName(welcomeID, "Welcome"): "<p>Welcome!</p>",
+		exportFileName(otherID, "Welcome"):   "<p>Other</p>",
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %s", err)
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("exported %d files, want %d", len(entries), len(want))
+	}
+	for name, content := range want {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("ReadFile: %s", err)
+		}
+		if string(got) != content {
+			t.Errorf("%s = %q, want %q", name, got, content)
+		}
+	}
+
+	// Exporting again leaves the unchanged files untouched.
+	welcomePath := filepath.Join(dir, exportFileName(welcomeID, "Welcome"))
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(welcomePath, old, old); err != nil {
+		t.Fatalf("Chtimes: %s", err)
+	}
+	if _, diags := h.readDataSource("pocinfobipemails_email_export", config); hasError(diags) {
+		t.Fatalf("re-read: %s", diagnosticsString(diags))
+	}
+	info, err := os.Stat(welcomePath)
+	if err != nil {
+		t.Fatalf("Stat: %s", err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("%s was rewritten by an export without changes", welcomePath)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmailLintDataSource{}
+
+func NewEmailLintDataSource() datasource.DataSource {
+	return &EmailLintDataSource{}
+}
+
+// EmailLintDataSource lints email html without calling the Infobip API.
+type EmailLintDataSource struct{}
+
+// EmailLintDataSourceModel describes the data source data model.
+type EmailLintDataSourceModel struct {
+	Html     types.String `tfsdk:"html"`
+	Warnings []string     `tfsdk:"warnings"`
+	Valid    types.Bool   `tfsdk:"valid"`
+}
+
+func (d *EmailLintDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_lint"
+}
+
+func (d *EmailLintDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lints email html without calling the Infobip API, using the same checks as the email template resource.",
+		Attributes: map[string]schema.Attribute{
+			"html": schema.StringAttribute{
+				Description: "HTML content to lint.",
+				Required:    true,
+			},
+			"warnings": schema.ListAttribute{
+				Description: "Problems found in the html: unclosed tags, a missing unsubscribe link, links to local hosts and bodies large enough to be clipped.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"valid": schema.BoolAttribute{
+				Description: "Whether the html passed every check.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *EmailLintDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EmailLintDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Warnings = lintHTML(data.Html.ValueString())
+	data.Valid = types.BoolValue(len(data.Warnings) == 0)
+
+	tflog.Trace(ctx, "read email lint data source", map[string]any{"warnings": len(data.Warnings)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
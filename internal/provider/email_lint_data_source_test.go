@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailLintDataSource(t *testing.T) {
+	const footer = `<p><a href="https://example.com/unsubscribe">Unsubscribe</a></p>`
+
+	cases := map[string]struct {
+		html     string
+		warnings []string
+	}{
+		"clean": {
+			html: `<html><body><p>Hello<br>there</p><img src="https://example.com/a.png"/>` + footer + `</body></html>`,
+		},
+		"unclosed tag": {
+			html:     `<html><body><div><p>Hello</p>` + footer + `</body></html>`,
+			warnings: []string{"tag <div> is not closed"},
+		},
+		"stray closing tag": {
+			html:     `<html><body></span>` + footer + `</body></html>`,
+			warnings: []string{"closing tag </span> has no matching opening tag"},
+		},
+		"missing unsubscribe": {
+			html:     `<html><body><p>Hello</p></body></html>`,
+			warnings: []string{"html has no unsubscribe link"},
+		},
+		"localhost links": {
+			html: `<html><body><a href="http://localhost:3000/x">x</a><img src="http://127.0.0.1/a.png">` + footer + `</body></html>`,
+			warnings: []string{
+				`href "http://localhost:3000/x" points to a local host`,
+				`src "http://127.0.0.1/a.png" points to a local host`,
+			},
+		},
+		"oversized": {
+			html:     `<html><body>` + strings.Repeat("a", htmlClipBytes) + footer + `</body></html>`,
+			warnings: []string{"bodies over 104448 bytes are clipped by Gmail"},
+		},
+	}
+
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state, diags := h.readDataSource("pocinfobipemails_email_lint", map[string]tftypes.Value{
+				"html": tfString(tc.html),
+			})
+			if hasError(diags) {
+				t.Fatalf("read: %s", diagnosticsString(diags))
+			}
+
+			var attrs map[string]tftypes.Value
+			_ = state.As(&attrs)
+
+			var valid bool
+			_ = attrs["valid"].As(&valid)
+			if valid != (len(tc.warnings) == 0) {
+				t.Errorf("valid = %t, want %t", valid, len(tc.warnings) == 0)
+			}
+
+			var items []tftypes.Value
+			_ = attrs["warnings"].As(&items)
+			got := []string{}
+			for _, item := range items {
+				var s string
+				_ = item.As(&s)
+				got = append(got, s)
+			}
+
+			if len(got) != len(tc.warnings) {
+				t.Fatalf("warnings = %q, want %q", got, tc.warnings)
+			}
+			for i := range got {
+				if !strings.Contains(got[i], tc.warnings[i]) {
+					t.Errorf("warning %d = %q, want it to contain %q", i, got[i], tc.warnings[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// senderSuppressionTypes are the suppression lists checked for the sender.
+var senderSuppressionTypes = []email.ApiSuppressionType{
+	email.APISUPPRESSIONTYPE_BOUNCE,
+	email.APISUPPRESSIONTYPE_COMPLAINT,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmailSenderCheckDataSource{}
+
+func NewEmailSenderCheckDataSource() datasource.DataSource {
+	return &EmailSenderCheckDataSource{}
+}
+
+// EmailSenderCheckDataSource checks whether a from address can send email.
+type EmailSenderCheckDataSource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+}
+
+// EmailSenderCheckDataSourceModel describes the data source data model.
+type EmailSenderCheckDataSourceModel struct {
+	From    types.String `tfsdk:"from"`
+	Usable  types.Bool   `tfsdk:"usable"`
+	Reasons []string     `tfsdk:"reasons"`
+}
+
+func (d *EmailSenderCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_sender_check"
+}
+
+func (d *EmailSenderCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether a from address can be used for sending: its domain must be added to the account, active and not blocked, " +
+			"and the address must not be on the domain's bounce or complaint suppression lists.",
+		Attributes: map[string]schema.Attribute{
+			"from": schema.StringAttribute{
+				Description: "Sender email address to check, such as `Name <user@example.com>`.",
+				Required:    true,
+			},
+			"usable": schema.BoolAttribute{
+				Description: "Whether the address passed every check.",
+				Computed:    true,
+			},
+			"reasons": schema.ListAttribute{
+				Description: "Why the address is not usable. Empty when usable.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *EmailSenderCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.infobipClient = pd.client
+	d.apiKey = pd.apiKey
+	d.authSchemeKey = pd.authSchemeKey
+	d.logRedactPatterns = pd.logRedactPatterns
+	d.correlationID = pd.correlationID
+	d.organizationID = pd.organizationID
+	d.warningsAsErrors = pd.warningsAsErrors
+	d.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+}
+
+func (d *EmailSenderCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = redactLogs(ctx, d.logRedactPatterns)
+	ctx = correlateLogs(ctx, d.correlationID)
+	ctx = collectRateLimits(ctx, d.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(d.warningsAsErrors, &resp.Diagnostics)
+
+	var data EmailSenderCheckDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reasons, err := d.check(ctx, data.From.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Checking Email Sender",
+			"An error was encountered while checking sender "+data.From.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	data.Reasons = reasons
+	data.Usable = types.BoolValue(len(reasons) == 0)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// check returns the reasons why from cannot be used for sending.
+func (d *EmailSenderCheckDataSource) check(ctx context.Context, from string) ([]string, error) {
+	reasons := []string{}
+
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return append(reasons, fmt.Sprintf("%q is not a valid email address: %s", from, err)), nil
+	}
+	address := strings.ToLower(addr.Address)
+	_, domain, _ := strings.Cut(address, "@")
+
+	auth := infobipAuthContext(ctx, d.apiKey, d.authSchemeKey, d.organizationID)
+
+	domainDetails, httpResponse, err := d.infobipClient.
+		EmailAPI.
+		GetDomainDetails(auth, domain).
+		Execute()
+
+	tflog.Debug(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err != nil {
+		if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+			return append(reasons, fmt.Sprintf("domain %s is not added to the Infobip account", domain)), nil
+		}
+		return nil, fmt.Errorf("reading domain %s: %w", domain, err)
+	}
+
+	if domainDetails == nil || domainDetails.Active == nil || !*domainDetails.Active {
+		reasons = append(reasons, fmt.Sprintf("domain %s is not verified", domain))
+	}
+	if domainDetails != nil && domainDetails.Blocked != nil && *domainDetails.Blocked {
+		reasons = append(reasons, fmt.Sprintf("domain %s is blocked", domain))
+	}
+
+	for _, suppressionType := range senderSuppressionTypes {
+		suppressions, httpResponse, err := d.infobipClient.
+			EmailAPI.
+			GetSuppressions(auth).
+			DomainName(domain).
+			Type_(suppressionType).
+			EmailAddress(address).
+			Execute()
+
+		tflog.Debug(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s suppressions for %s: %w", suppressionType, domain, err)
+		}
+
+		if suppressions != nil && len(suppressions.Results) > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s is on the %s suppression list", address, suppressionType))
+		}
+	}
+
+	return reasons, nil
+}
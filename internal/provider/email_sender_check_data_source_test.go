@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailSenderCheckDataSource(t *testing.T) {
+	cases := map[string]struct {
+		from    string
+		reasons []string
+	}{
+		"verified": {
+			from: "Sender <news@verified.example.com>",
+		},
+		"unverified domain": {
+			from:    "news@pending.example.com",
+			reasons: []string{"domain pending.example.com is not verified"},
+		},
+		"blocked domain": {
+			from:    "news@blocked.example.com",
+			reasons: []string{"domain blocked.example.com is blocked"},
+		},
+		"unknown domain": {
+			from:    "news@unknown.example.com",
+			reasons: []string{"domain unknown.example.com is not added to the Infobip account"},
+		},
+		"suppressed address": {
+			from:    "bounced@verified.example.com",
+			reasons: []string{"bounced@verified.example.com is on the BOUNCE suppression list"},
+		},
+		"invalid address": {
+			from:    "not an address",
+			reasons: []string{"is not a valid email address"},
+		},
+	}
+
+	mock := newMockInfobip(t)
+	mock.domains["verified.example.com"] = mockDomain{active: true}
+	mock.domains["pending.example.com"] = mockDomain{active: false}
+	mock.domains["blocked.example.com"] = mockDomain{active: true, blocked: true}
+	mock.suppressions["bounced@verified.example.com"] = []string{"BOUNCE"}
+	h := newTestHarness(t, mock, nil)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state, diags := h.readDataSource("pocinfobipemails_email_sender_check", map[string]tftypes.Value{
+				"from": tfString(tc.from),
+			})
+			if hasError(diags) {
+				t.Fatalf("read: %s", diagnosticsString(diags))
+			}
+
+			var attrs map[string]tftypes.Value
+			_ = state.As(&attrs)
+
+			var usable bool
+			_ = attrs["usable"].As(&usable)
+			if usable != (len(tc.reasons) == 0) {
+				t.Errorf("usable = %t, want %t", usable, len(tc.reasons) == 0)
+			}
+
+			var items []tftypes.Value
+			_ = attrs["reasons"].As(&items)
+			got := []string{}
+			for _, item := range items {
+				var s string
+				_ = item.As(&s)
+				got = append(got, s)
+			}
+
+			if len(got) != len(tc.reasons) {
+				t.Fatalf("reasons = %q, want %q", got, tc.reasons)
+			}
+			for i := range got {
+				if !strings.Contains(got[i], tc.reasons[i]) {
+					t.Errorf("reason %d = %q, want it to contain %q", i, got[i], tc.reasons[i])
+				}
+			}
+		})
+	}
+}
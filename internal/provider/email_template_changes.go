@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// emailTemplateChanges returns log fields describing which configurable
+// attributes differ between state and plan. Values are never logged, since
+// addresses and content can be sensitive: each changed attribute is
+// summarized as the hashes of its old and new values.
+func emailTemplateChanges(state, plan EmailTemplateResourceModel) map[string]any {
+	attributes := []struct {
+		name     string
+		old, new types.String
+	}{
+		{"name", state.Name, plan.Name},
+		{"from", state.From, plan.From},
+		{"reply_to", state.ReplyTo, plan.ReplyTo},
+		{"subject", state.Subject, plan.Subject},
+		{"preheader", state.Preheader, plan.Preheader},
+		{"effective_preheader", state.EffectivePreheader, plan.EffectivePreheader},
+		{"html", state.Html, plan.Html},
+		{"landing_page", state.LandingPage, plan.LandingPage},
+	}
+
+	changed := []string{}
+	hashes := map[string]string{}
+	for _, attribute := range attributes {
+		if attribute.old.Equal(attribute.new) {
+			continue
+		}
+
+		changed = append(changed, attribute.name)
+		hashes[attribute.name] = valueHash(attribute.old) + " -> " + valueHash(attribute.new)
+	}
+
+	return map[string]any{
+		"changed_attributes": changed,
+		"hashes":             hashes,
+	}
+}
+
+// valueHash returns a short hash identifying a value without revealing it.
+func valueHash(value types.String) string {
+	switch {
+	case value.IsNull():
+		return "null"
+	case value.IsUnknown():
+		return "unknown"
+	}
+
+	sum := sha256.Sum256([]byte(value.ValueString()))
+
+	return hex.EncodeToString(sum[:6])
+}
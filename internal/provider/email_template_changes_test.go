@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEmailTemplateChanges(t *testing.T) {
+	state := EmailTemplateResourceModel{
+		Name:        types.StringValue("Welcome"),
+		From:        types.StringValue("sender@example.com"),
+		ReplyTo:     types.StringNull(),
+		Subject:     types.StringValue("Welcome aboard"),
+		Preheader:   types.StringValue("Hello"),
+		Html:        types.StringValue("<p>Secret offer</p>"),
+		LandingPage: types.StringValue(""),
+	}
+
+	t.Run("subject only", func(t *testing.T) {
+		plan := state
+		plan.Subject = types.StringValue("Top secret subject")
+
+		changes := emailTemplateChanges(state, plan)
+		if got := changes["changed_attributes"]; !reflect.DeepEqual(got, []string{"subject"}) {
+			t.Errorf("changed_attributes = %v, want [subject]", got)
+		}
+
+		hashes, _ := changes["hashes"].(map[string]string)
+		if len(hashes) != 1 || hashes["subject"] != valueHash(state.Subject)+" -> "+valueHash(plan.Subject) {
+			t.Errorf("hashes = %v, want only the subject hashes", hashes)
+		}
+		if logged := fmt.Sprint(changes); strings.Contains(logged, "secret") {
+			t.Errorf("log fields reveal values: %s", logged)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		changes := emailTemplateChanges(state, state)
+		if got := changes["changed_attributes"]; !reflect.DeepEqual(got, []string{}) {
+			t.Errorf("changed_attributes = %v, want none", got)
+		}
+	})
+
+	t.Run("html and reply_to", func(t *testing.T) {
+		plan := state
+		plan.Html = types.StringValue("<p>Secret offer, now 50% off</p>")
+		plan.ReplyTo = types.StringValue("support@example.com")
+
+		changes := emailTemplateChanges(state, plan)
+		if got := changes["changed_attributes"]; !reflect.DeepEqual(got, []string{"reply_to", "html"}) {
+			t.Errorf("changed_attributes = %v, want [reply_to html]", got)
+		}
+		hashes, _ := changes["hashes"].(map[string]string)
+		if !strings.HasPrefix(hashes["reply_to"], "null -> ") {
+			t.Errorf("reply_to hashes = %q, want a null old value", hashes["reply_to"])
+		}
+	})
+}
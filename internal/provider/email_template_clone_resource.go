@@ -0,0 +1,436 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EmailTemplateCloneResource{}
+
+func NewEmailTemplateCloneResource() resource.Resource {
+	return &EmailTemplateCloneResource{}
+}
+
+// EmailTemplateCloneResource creates a copy of an existing email template and
+// manages the copy independently of its source.
+type EmailTemplateCloneResource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+	// deleteGuard refuses unconfirmed bulk deletes.
+	deleteGuard *deleteGuard
+	// deadline stops API calls once the global deadline has passed.
+	deadline *apiDeadline
+}
+
+// EmailTemplateCloneResourceModel describes the resource data model.
+type EmailTemplateCloneResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	SourceID    types.String `tfsdk:"source_id"`
+	Name        types.String `tfsdk:"name"`
+	From        types.String `tfsdk:"from"`
+	ReplyTo     types.String `tfsdk:"reply_to"`
+	Subject     types.String `tfsdk:"subject"`
+	Preheader   types.String `tfsdk:"preheader"`
+	Html        types.String `tfsdk:"html"`
+	LandingPage types.String `tfsdk:"landing_page"`
+}
+
+func (r *EmailTemplateCloneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_template_clone"
+}
+
+func (r *EmailTemplateCloneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Fields that are not configured are copied from the source template on
+	// create, and then kept as stored.
+	copied := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: description + " Defaults to the value of the source template.",
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	subject := copied("Subject line of the copy. Must not be empty.")
+	subject.Validators = []validator.String{emptySubjectValidator{}}
+	html := copied("HTML content of the copy.")
+	html.Validators = []validator.String{htmlControlCharactersValidator{}}
+	html.PlanModifiers = append(html.PlanModifiers, htmlWhitespaceInsensitiveModifier{})
+
+	resp.Schema = schema.Schema{
+		Description: "Creates a copy of an existing Infobip Email Template, such as a starting point for a new campaign, and manages the copy. " +
+			"Later changes to the source template do not affect the copy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the copy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				Description: "ID of the email template to copy. Changing it creates a new copy.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name":      copied("Name of the copy."),
+			"from":      copied("Sender email address of the copy."),
+			"reply_to":  copied("Reply-to email address of the copy."),
+			"subject":   subject,
+			"preheader": copied("Preheader text of the copy."),
+			"html":      html,
+			"landing_page": schema.StringAttribute{
+				Description: "Associated landing page ID, if any, copied from the source template.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *EmailTemplateCloneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.infobipClient = pd.client
+	r.apiKey = pd.apiKey
+	r.authSchemeKey = pd.authSchemeKey
+	r.logRedactPatterns = pd.logRedactPatterns
+	r.correlationID = pd.correlationID
+	r.organizationID = pd.organizationID
+	r.warningsAsErrors = pd.warningsAsErrors
+	r.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+	r.deleteGuard = pd.deleteGuard
+	r.deadline = pd.deadline
+}
+
+func (r *EmailTemplateCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var plan EmailTemplateCloneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sourceID int64
+	if _, err := fmt.Sscanf(plan.SourceID.ValueString(), "%d", &sourceID); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_id"),
+			"Invalid Email Template ID",
+			fmt.Sprintf("source_id %s is not a valid email template ID.", plan.SourceID.String()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.deadline.check("read source email template ID " + plan.SourceID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+
+	source, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		GetEmailTemplate(auth).
+		ID(sourceID).
+		Execute()
+	if (err == nil && isEmptyEmailTemplate(source)) || (httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_id"),
+			"Source Email Template Not Found",
+			fmt.Sprintf("No email template has ID %d, so it cannot be copied.", sourceID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Email Template",
+			fmt.Sprintf("Could not read source email template ID %d: %s", sourceID, err),
+		)
+		return
+	}
+
+	plan.Name = clonedValue(plan.Name, source.Name)
+	plan.From = clonedValue(plan.From, source.From)
+	plan.ReplyTo = clonedValue(plan.ReplyTo, source.ReplyTo)
+	plan.Subject = clonedValue(plan.Subject, source.Subject)
+	plan.Preheader = clonedValue(plan.Preheader, source.Preheader)
+	plan.Html = clonedValue(plan.Html, source.HTML)
+	plan.LandingPage = types.StringValue(source.LandingPageID)
+	if plan.Subject.ValueString() == "" {
+		resp.Diagnostics.Append(emptySubjectDiagnostic(path.Root("subject")))
+		return
+	}
+
+	resp.Diagnostics.Append(r.deadline.check("create the email template copy")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	emailTemplate, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		CreateEmailTemplate(auth).
+		Name(plan.Name.ValueString()).
+		From(plan.From.ValueString()).
+		ReplyTo(plan.ReplyTo.ValueString()).
+		Subject(plan.Subject.ValueString()).
+		Preheader(plan.Preheader.ValueString()).
+		Html(plan.Html.ValueString()).
+		LandingPage(plan.LandingPage.ValueString()).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Email Template",
+			fmt.Sprintf("An error was encountered while copying email template ID %d: %s", sourceID, err),
+		)
+		return
+	}
+	if isEmptyEmailTemplate(emailTemplate) {
+		resp.Diagnostics.AddError(
+			"Invalid Email Template ID",
+			fmt.Sprintf("Infobip reported success but returned template ID %d, which is not a valid ID. "+
+				"The copy may have been created; check the Infobip account.", emailTemplateID(emailTemplate)),
+		)
+		return
+	}
+	tflog.Info(ctx, "Copied email template", map[string]any{"source_id": sourceID, "id": emailTemplate.ID})
+
+	setEmailTemplateClone(&plan, emailTemplate)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *EmailTemplateCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var state EmailTemplateCloneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(state.ID.ValueString(), "%d", &id); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.deadline.check("read email template ID " + state.ID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+
+	emailTemplate, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		GetEmailTemplate(auth).
+		ID(id).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if (err == nil && isEmptyEmailTemplate(emailTemplate)) || (httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound) {
+		tflog.Warn(ctx, "Email template copy not found; removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Email Template",
+			"Could not read email template ID "+state.ID.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	setEmailTemplateClone(&state, emailTemplate)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EmailTemplateCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var plan, state EmailTemplateCloneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(state.ID.ValueString(), "%d", &id); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.deadline.check("update email template ID " + state.ID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+
+	// Unconfigured fields are planned as stored, so every field is known.
+	emailTemplate, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		UpdateEmailTemplate(auth).
+		ID(id).
+		Name(plan.Name.ValueString()).
+		From(plan.From.ValueString()).
+		ReplyTo(plan.ReplyTo.ValueString()).
+		Subject(plan.Subject.ValueString()).
+		Preheader(plan.Preheader.ValueString()).
+		Html(plan.Html.ValueString()).
+		LandingPage(plan.LandingPage.ValueString()).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Email Template",
+			"An error was encountered while updating email template ID "+state.ID.String()+": "+err.Error(),
+		)
+		return
+	}
+	if isEmptyEmailTemplate(emailTemplate) {
+		resp.Diagnostics.AddError(
+			"Invalid Email Template ID",
+			fmt.Sprintf("Infobip reported success updating email template ID %s but returned template ID %d, which is not a valid ID.",
+				state.ID.String(), emailTemplateID(emailTemplate)),
+		)
+		return
+	}
+
+	setEmailTemplateClone(&plan, emailTemplate)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *EmailTemplateCloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var state EmailTemplateCloneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.deleteGuard.allow(); err != nil {
+		resp.Diagnostics.AddError(
+			"Bulk Delete Not Confirmed",
+			"Could not delete email template ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(state.ID.ValueString(), "%d", &id); err != nil {
+		return
+	}
+
+	resp.Diagnostics.Append(r.deadline.check("delete email template ID " + state.ID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+
+	httpResponse, err := r.infobipClient.
+		EmailAPI.
+		RemoveEmailTemplate(auth).
+		ID(id).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err != nil && (httpResponse == nil || httpResponse.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Email Template",
+			"An error was encountered while deleting email template ID "+state.ID.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// clonedValue returns the configured value of a copied field, or the value of
+// the source template when it is not configured.
+func clonedValue(configured types.String, source string) types.String {
+	if configured.IsNull() || configured.IsUnknown() {
+		return types.StringValue(source)
+	}
+
+	return configured
+}
+
+// setEmailTemplateClone maps an API template to the copy's attributes. The
+// html is kept as planned when it only differs from the stored html by
+// whitespace, like the email template resource does.
+func setEmailTemplateClone(m *EmailTemplateCloneResourceModel, emailTemplate *email.CreateEmailTemplateResponse) {
+	m.ID = types.StringValue(fmt.Sprintf("%d", emailTemplate.ID))
+	m.Name = types.StringValue(emailTemplate.Name)
+	m.From = types.StringValue(emailTemplate.From)
+	m.ReplyTo = types.StringValue(emailTemplate.ReplyTo)
+	m.Subject = types.StringValue(emailTemplate.Subject)
+	m.Preheader = types.StringValue(emailTemplate.Preheader)
+	if normalizeHTML(m.Html.ValueString()) != normalizeHTML(emailTemplate.HTML) {
+		m.Html = types.StringValue(emailTemplate.HTML)
+	}
+	m.LandingPage = types.StringValue(emailTemplate.LandingPageID)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateCloneResource(t *testing.T) {
+	source := email.CreateEmailTemplateResponse{
+		Name:          "Spring sale",
+		From:          "Shop <shop@example.com>",
+		ReplyTo:       "support@example.com",
+		Subject:       "Spring is here",
+		Preheader:     "Up to 50% off",
+		HTML:          "<html><body><h1>Spring</h1></body></html>",
+		LandingPageID: "lp-1",
+	}
+
+	cases := map[string]struct {
+		overrides map[string]tftypes.Value
+		want      map[string]string
+	}{
+		"without overrides": {
+			want: map[string]string{
+				"name":         source.Name,
+				"from":         source.From,
+				"reply_to":     source.ReplyTo,
+				"subject":      source.Subject,
+				"preheader":    source.Preheader,
+				"html":         source.HTML,
+				"landing_page": source.LandingPageID,
+			},
+		},
+		"with overrides": {
+			overrides: map[string]tftypes.Value{
+				"name":    tfString("Summer sale"),
+				"subject": tfString("Summer is here"),
+				"html":    tfString("<html><body><h1>Summer</h1></body></html>"),
+			},
+			want: map[string]string{
+				"name":         "Summer sale",
+				"from":         source.From,
+				"subject":      "Summer is here",
+				"html":         "<html><body><h1>Summer</h1></body></html>",
+				"landing_page": source.LandingPageID,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			sourceID := mock.addTemplate(source)
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template_clone")
+
+			config := map[string]tftypes.Value{"source_id": tfString(fmt.Sprintf("%d", sourceID))}
+			for attr, value := range tc.overrides {
+				config[attr] = value
+			}
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+
+			id := r.stringAttr("id")
+			if id == fmt.Sprintf("%d", sourceID) || len(mock.templates) != 2 {
+				t.Fatalf("id = %s, templates = %d, want a new template", id, len(mock.templates))
+			}
+			for attr, value := range tc.want {
+				if got := r.stringAttr(attr); got != value {
+					t.Errorf("%s = %q, want %q", attr, got, value)
+				}
+			}
+
+			// The copy is managed independently of the source.
+			mock.mu.Lock()
+			mock.templates[sourceID].Subject = "Changed source"
+			mock.mu.Unlock()
+			if diags := r.refresh(); hasError(diags) {
+				t.Fatalf("refresh: %s", diagnosticsString(diags))
+			}
+			if got := r.stringAttr("subject"); got != tc.want["subject"] {
+				t.Errorf("subject after the source changed = %q, want %q", got, tc.want["subject"])
+			}
+
+			config["preheader"] = tfString("New preheader")
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+			mock.mu.Lock()
+			copied, original := mock.templates[mustParseID(t, id)], mock.templates[sourceID]
+			mock.mu.Unlock()
+			if copied.Preheader != "New preheader" || copied.Name != tc.want["name"] || original.Preheader != source.Preheader {
+				t.Errorf("copy = %+v, source = %+v, want only the copy updated", copied, original)
+			}
+
+			if diags := r.destroy(); hasError(diags) {
+				t.Fatalf("destroy: %s", diagnosticsString(diags))
+			}
+			if _, ok := mock.templates[sourceID]; !ok || len(mock.templates) != 1 {
+				t.Errorf("templates = %d, want only the source left", len(mock.templates))
+			}
+		})
+	}
+}
+
+func TestEmailTemplateCloneResource_SourceNotFound(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template_clone")
+
+	diags := r.apply(map[string]tftypes.Value{"source_id": tfString("404")})
+	if !strings.Contains(diagnosticsString(diags), "Source Email Template Not Found") {
+		t.Fatalf("diagnostics = %s, want Source Email Template Not Found", diagnosticsString(diags))
+	}
+	if len(mock.templates) != 0 {
+		t.Errorf("templates = %d, want none created", len(mock.templates))
+	}
+}
+
+func mustParseID(t *testing.T, id string) int64 {
+	t.Helper()
+
+	var n int64
+	if _, err := fmt.Sscanf(id, "%d", &n); err != nil {
+		t.Fatalf("id %q: %s", id, err)
+	}
+
+	return n
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmailTemplateHtmlDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &EmailTemplateHtmlDataSource{}
+
+func NewEmailTemplateHtmlDataSource() datasource.DataSource {
+	return &EmailTemplateHtmlDataSource{}
+}
+
+// EmailTemplateHtmlDataSource exposes the html of a single template, looked
+// up by id or name, for export pipelines.
+type EmailTemplateHtmlDataSource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+}
+
+// EmailTemplateHtmlDataSourceModel describes the data source data model.
+type EmailTemplateHtmlDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Html    types.String `tfsdk:"html"`
+	HtmlRaw types.String `tfsdk:"html_raw"`
+	Subject types.String `tfsdk:"subject"`
+}
+
+func (d *EmailTemplateHtmlDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_template_html"
+}
+
+func (d *EmailTemplateHtmlDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the html and subject of a single Infobip Email Template, looked up by `id` or `name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the email template. Exactly one of `id` and `name` must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the email template. Fails if no template or several templates have the name.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"html": schema.StringAttribute{
+				Description: "HTML content of the email template, normalized like the `html` of `pocinfobipemails_email_template`.",
+				Computed:    true,
+			},
+			"html_raw": schema.StringAttribute{
+				Description: "HTML content of the email template exactly as stored by Infobip.",
+				Computed:    true,
+			},
+			"subject": schema.StringAttribute{
+				Description: "Subject line of the email template.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *EmailTemplateHtmlDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data EmailTemplateHtmlDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.ID.IsUnknown() || data.Name.IsUnknown() {
+		return
+	}
+
+	if data.ID.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Email Template Lookup",
+			"Exactly one of id and name must be set.",
+		)
+	}
+}
+
+func (d *EmailTemplateHtmlDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.infobipClient = pd.client
+	d.apiKey = pd.apiKey
+	d.authSchemeKey = pd.authSchemeKey
+	d.logRedactPatterns = pd.logRedactPatterns
+	d.correlationID = pd.correlationID
+	d.organizationID = pd.organizationID
+	d.warningsAsErrors = pd.warningsAsErrors
+	d.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+}
+
+func (d *EmailTemplateHtmlDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = redactLogs(ctx, d.logRedactPatterns)
+	ctx = correlateLogs(ctx, d.correlationID)
+	ctx = collectRateLimits(ctx, d.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(d.warningsAsErrors, &resp.Diagnostics)
+
+	var data EmailTemplateHtmlDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := infobipAuthContext(ctx, d.apiKey, d.authSchemeKey, d.organizationID)
+
+	var id int64
+	if !data.Name.IsNull() {
+		matches, err := findEmailTemplatesByName(auth, d.infobipClient, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing Email Templates",
+				"Could not look up the email template named "+data.Name.String()+": "+err.Error(),
+			)
+			return
+		}
+
+		switch len(matches) {
+		case 0:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Email Template Not Found",
+				fmt.Sprintf("No email template is named %s.", data.Name.String()),
+			)
+			return
+		case 1:
+			id = matches[0].GetId()
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Ambiguous Email Template Name",
+				fmt.Sprintf("%d email templates are named %s; look the template up by id instead.", len(matches), data.Name.String()),
+			)
+			return
+		}
+	} else if _, err := fmt.Sscanf(data.ID.ValueString(), "%d", &id); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Email Template ID",
+			fmt.Sprintf("id %s is not a valid email template ID.", data.ID.String()),
+		)
+		return
+	}
+
+	emailTemplate, _, err := d.infobipClient.
+		EmailAPI.
+		GetEmailTemplate(auth).
+		ID(id).
+		Execute()
+	if err == nil && isEmptyEmailTemplate(emailTemplate) {
+		err = fmt.Errorf("the template was not found")
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Email Template",
+			fmt.Sprintf("Could not read email template ID %d: %s", id, err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%d", emailTemplate.ID))
+	data.Name = types.StringValue(emailTemplate.Name)
+	data.Html = types.StringValue(normalizeHTML(emailTemplate.HTML))
+	data.HtmlRaw = types.StringValue(emailTemplate.HTML)
+	data.Subject = types.StringValue(emailTemplate.Subject)
+	tflog.Trace(ctx, "read email template html data source", map[string]any{"id": id})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
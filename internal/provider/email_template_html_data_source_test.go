@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateHtmlDataSource(t *testing.T) {
+	const html = "<html>\n  <body><p>Welcome!</p></body>\n</html>"
+
+	mock := newMockInfobip(t)
+	id := fmt.Sprintf("%d", mock.addTemplate(email.CreateEmailTemplateResponse{Name: "welcome", Subject: "Hi", HTML: html}))
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "reset", Subject: "Reset", HTML: "<p>Reset</p>"})
+	h := newTestHarness(t, mock, nil)
+
+	lookups := map[string]map[string]tftypes.Value{
+		"by id":   {"id": tfString(id)},
+		"by name": {"name": tfString("welcome")},
+	}
+	for name, config := range lookups {
+		t.Run(name, func(t *testing.T) {
+			state, diags := h.readDataSource("pocinfobipemails_email_template_html", config)
+			if hasError(diags) {
+				t.Fatalf("read: %s", diagnosticsString(diags))
+			}
+
+			var attrs map[string]tftypes.Value
+			_ = state.As(&attrs)
+			want := map[string]string{
+				"id":       id,
+				"name":     "welcome",
+				"html":     normalizeHTML(html),
+				"html_raw": html,
+				"subject":  "Hi",
+			}
+			for attr, value := range want {
+				if !attrs[attr].Equal(tfString(value)) {
+					t.Errorf("%s = %s, want %q", attr, attrs[attr], value)
+				}
+			}
+		})
+	}
+}
+
+func TestEmailTemplateHtmlDataSource_AmbiguousName(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "welcome", Subject: "Hi"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "welcome", Subject: "Hello"})
+	h := newTestHarness(t, mock, nil)
+
+	_, diags := h.readDataSource("pocinfobipemails_email_template_html", map[string]tftypes.Value{
+		"name": tfString("welcome"),
+	})
+	if !strings.Contains(diagnosticsString(diags), "Ambiguous Email Template Name") {
+		t.Errorf("diagnostics = %s, want an ambiguous name error", diagnosticsString(diags))
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// importAllID is the import ID that asks to import every template of the
+// account.
+const importAllID = "@all"
+
+// importAllExample is the configuration suggested to import every template
+// of the account, since a single import ID can only import one template.
+const importAllExample = `data "pocinfobipemails_email_templates" "all" {}
+
+import {
+  for_each = { for t in data.pocinfobipemails_email_templates.all.templates : t.id => t }
+  to       = pocinfobipemails_email_template.imported[each.key]
+  id       = each.key
+}`
+
+// importNameSeparator separates the template ID of an import ID from the
+// name the template is expected to have, as in "42@Welcome".
+const importNameSeparator = "@"
+
+// parseImportID returns the template ID of an import ID and the name the
+// template is expected to have, if the ID pins one, or all when it is
+// importAllID. Template IDs are positive integers.
+func parseImportID(raw string) (id int64, expectedName string, all bool, err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == importAllID {
+		return 0, "", true, nil
+	}
+
+	rawID, expectedName, pinned := strings.Cut(raw, importNameSeparator)
+	if pinned && expectedName == "" {
+		return 0, "", false, fmt.Errorf("import ID %q has no template name after %q; use <id>%s<expected-name>, or the ID alone", raw, importNameSeparator, importNameSeparator)
+	}
+
+	id, err = strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, "", false, fmt.Errorf("import ID %q is not an email template ID; use the numeric ID shown in the Infobip web interface, optionally followed by %s and the expected template name, or %s", raw, importNameSeparator, importAllID)
+	}
+
+	return id, expectedName, false, nil
+}
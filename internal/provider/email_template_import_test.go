@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestParseImportID(t *testing.T) {
+	cases := map[string]struct {
+		raw      string
+		wantID   int64
+		wantName string
+		wantAll  bool
+		wantErr  bool
+	}{
+		"template ID":         {raw: "205000000016125", wantID: 205000000016125},
+		"surrounding spaces":  {raw: " 42\n", wantID: 42},
+		"expected name":       {raw: "42@Welcome email", wantID: 42, wantName: "Welcome email"},
+		"name with separator": {raw: "42@News@Weekly", wantID: 42, wantName: "News@Weekly"},
+		"empty name":          {raw: "42@", wantErr: true},
+		"name without ID":     {raw: "Welcome@42", wantErr: true},
+		"all":                 {raw: "@all", wantAll: true},
+		"all with spaces":     {raw: " @all ", wantAll: true},
+		"other sentinel":      {raw: "@ALL", wantErr: true},
+		"name":                {raw: "Welcome", wantErr: true},
+		"zero":                {raw: "0", wantErr: true},
+		"negative":            {raw: "-5", wantErr: true},
+		"empty":               {raw: "", wantErr: true},
+		"ID with a separator": {raw: "42,43", wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, expectedName, all, err := parseImportID(tc.raw)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseImportID(%q) error = %v, want error %t", tc.raw, err, tc.wantErr)
+			}
+			if id != tc.wantID || expectedName != tc.wantName || all != tc.wantAll {
+				t.Errorf("parseImportID(%q) = %d, %q, %t, want %d, %q, %t", tc.raw, id, expectedName, all, tc.wantID, tc.wantName, tc.wantAll)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_ImportExpectedName(t *testing.T) {
+	mock := newMockInfobip(t)
+	id := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Welcome", Subject: "Hi"})
+	h := newTestHarness(t, mock, nil)
+
+	cases := map[string]struct {
+		importID string
+		wantErr  string
+	}{
+		"plain ID":          {importID: fmt.Sprintf("%d", id)},
+		"matching name":     {importID: fmt.Sprintf("%d@Welcome", id)},
+		"mismatching name":  {importID: fmt.Sprintf("%d@Password reset", id), wantErr: "Email Template Name Mismatch"},
+		"missing template":  {importID: fmt.Sprintf("%d@Welcome", id+1), wantErr: "Error Importing Email Template"},
+		"name differs case": {importID: fmt.Sprintf("%d@welcome", id), wantErr: "Email Template Name Mismatch"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp, err := h.server.ImportResourceState(h.ctx, &tfprotov6.ImportResourceStateRequest{
+				TypeName: "pocinfobipemails_email_template",
+				ID:       tc.importID,
+			})
+			if err != nil {
+				t.Fatalf("ImportResourceState: %s", err)
+			}
+
+			got := diagnosticsString(resp.Diagnostics)
+			if tc.wantErr == "" {
+				if hasError(resp.Diagnostics) {
+					t.Fatalf("import: %s", got)
+				}
+				if len(resp.ImportedResources) != 1 {
+					t.Fatalf("imported %d resources, want 1", len(resp.ImportedResources))
+				}
+				return
+			}
+			if !hasError(resp.Diagnostics) || !strings.Contains(got, tc.wantErr) {
+				t.Errorf("diagnostics = %s, want %q", got, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_ImportAll(t *testing.T) {
+	mock := newMockInfobip(t)
+	for _, name := range []string{"welcome", "reset"} {
+		mock.addTemplate(email.CreateEmailTemplateResponse{Name: name, Subject: name})
+	}
+	h := newTestHarness(t, mock, nil)
+
+	resp, err := h.server.ImportResourceState(h.ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "pocinfobipemails_email_template",
+		ID:       importAllID,
+	})
+	if err != nil {
+		t.Fatalf("ImportResourceState: %s", err)
+	}
+
+	got := diagnosticsString(resp.Diagnostics)
+	if !hasError(resp.Diagnostics) || !strings.Contains(got, "cannot import the 2 email templates") || !strings.Contains(got, "import {") {
+		t.Errorf("diagnostics = %s, want an error with the import block pattern", got)
+	}
+}
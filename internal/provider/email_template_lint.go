@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apparentlymart/go-textseg/v15/textseg"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ampMarkup matches AMP for Email markup: the ⚡4email or amp4email attribute
+// of the html element, or an amp-* component such as <amp-img>.
+var ampMarkup = regexp.MustCompile(`(?i)<html\b[^>]*\s(?:⚡4email|amp4email)\b|<amp-[a-z]`)
+
+// noReplyLocalPart matches local parts such as "noreply", "no-reply" and
+// "do_not_reply".
+var noReplyLocalPart = regexp.MustCompile(`(?i)^(no|do[-_.]?not)[-_.]?reply`)
+
+// lintEmailTemplate returns warnings for configurations that are valid but
+// most likely mistakes, including the lintHTML checks. Unknown or null values
+// are skipped.
+func lintEmailTemplate(plan EmailTemplateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !plan.Html.IsUnknown() && !plan.Html.IsNull() {
+		for _, warning := range lintHTML(plan.Html.ValueString()) {
+			diags.AddAttributeWarning(path.Root("html"), "HTML Lint Warning", warning)
+		}
+	}
+
+	if plan.From.IsUnknown() || plan.ReplyTo.IsUnknown() || plan.ReplyTo.IsNull() {
+		return diags
+	}
+
+	from := emailAddress(plan.From.ValueString())
+	replyTo := emailAddress(plan.ReplyTo.ValueString())
+	if from == "" || replyTo == "" {
+		return diags
+	}
+
+	if from == replyTo {
+		diags.AddAttributeWarning(
+			path.Root("reply_to"),
+			"Redundant Reply-To Address",
+			"reply_to is the same address as from, so it has no effect. Remove it, or check whether from and reply_to were swapped.",
+		)
+	}
+
+	if isNoReplyAddress(from) && isNoReplyAddress(replyTo) {
+		diags.AddAttributeWarning(
+			path.Root("reply_to"),
+			"Replies Cannot Be Received",
+			"Both from and reply_to are no-reply addresses, so no one can receive replies to this email. "+
+				"Set reply_to to a monitored mailbox, or check whether from and reply_to were swapped.",
+		)
+	}
+
+	return diags
+}
+
+// checkDomainAlignment warns when the domains of from and reply_to differ,
+// since mismatched domains hurt deliverability and look like phishing. It
+// backs the provider's lint_domain_alignment setting. Unknown or null values
+// and addresses without a domain are skipped.
+func checkDomainAlignment(plan EmailTemplateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.From.IsUnknown() || plan.From.IsNull() || plan.ReplyTo.IsUnknown() || plan.ReplyTo.IsNull() {
+		return diags
+	}
+
+	fromDomain := addressDomain(emailAddress(plan.From.ValueString()))
+	replyToDomain := addressDomain(emailAddress(plan.ReplyTo.ValueString()))
+	if fromDomain == "" || replyToDomain == "" || fromDomain == replyToDomain {
+		return diags
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("reply_to"),
+		"Misaligned Reply-To Domain",
+		fmt.Sprintf("reply_to is at %s but from is at %s. Mismatched domains can hurt deliverability and make the email look like phishing; "+
+			"use a reply_to address at %s, or unset the provider's lint_domain_alignment.", replyToDomain, fromDomain, fromDomain),
+	)
+
+	return diags
+}
+
+// checkAMP informs when the html contains AMP for Email markup, since AMP
+// content is incompatible with features that rewrite the html, such as
+// click and open tracking. It backs the provider's detect_amp setting.
+func checkAMP(plan EmailTemplateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.Html.IsUnknown() || plan.Html.IsNull() || !ampMarkup.MatchString(plan.Html.ValueString()) {
+		return diags
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("html"),
+		"AMP for Email Markup Detected",
+		"The html contains AMP for Email markup. Click tracking, open tracking and other Infobip features that rewrite the html "+
+			"invalidate AMP documents, and email clients without AMP support show no content for them. "+
+			"Disable tracking for messages sent with this template, and keep a plain html version for other clients. "+
+			"Unset the provider's detect_amp to silence this warning.",
+	)
+
+	return diags
+}
+
+// checkHTMLWellFormed returns an error for every unbalanced tag in the html,
+// as found by lintUnclosedTags. It backs the provider's strict_html setting.
+func checkHTMLWellFormed(plan EmailTemplateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.Html.IsUnknown() || plan.Html.IsNull() {
+		return diags
+	}
+
+	for _, problem := range lintUnclosedTags(plan.Html.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("html"),
+			"Malformed HTML",
+			problem+". Unbalanced tags can render as a broken email; fix the html, or unset the provider's strict_html.",
+		)
+	}
+
+	return diags
+}
+
+// checkPreheaderSegments warns when preheader_segments has more than one
+// segment, since Infobip stores a single preheader and they are joined.
+func checkPreheaderSegments(ctx context.Context, plan EmailTemplateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.PreheaderSegments.IsUnknown() || len(plan.PreheaderSegments.Elements()) < 2 {
+		return diags
+	}
+
+	joined := joinPreheaderSegments(ctx, plan.PreheaderSegments)
+	if joined.IsUnknown() {
+		return diags
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("preheader_segments"),
+		"Preheader Segments Collapsed",
+		fmt.Sprintf("Infobip supports a single preheader for every email client, so the %d segments are sent as %q.",
+			len(plan.PreheaderSegments.Elements()), joined.ValueString()),
+	)
+
+	return diags
+}
+
+// checkPreheaderLength warns when the preheader is longer than maxLength
+// characters, since email clients truncate it in the preview. It is not a
+// lint check, so it always runs. A maxLength below 1 disables the check.
+func checkPreheaderLength(preheader types.String, maxLength int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if maxLength < 1 || preheader.IsUnknown() || preheader.IsNull() {
+		return diags
+	}
+
+	if length := textLength(preheader.ValueString()); length > maxLength {
+		diags.AddAttributeWarning(
+			path.Root("preheader"),
+			"Preheader Will Be Truncated",
+			fmt.Sprintf("preheader is %d characters long; most email clients show at most %d in the preview and truncate the rest. "+
+				"Shorten it, or raise the provider's preheader_max_length.", length, maxLength),
+		)
+	}
+
+	return diags
+}
+
+// checkSubjectLength warns when the subject is longer than maxLength
+// characters, since email clients truncate it in the inbox. Like
+// checkPreheaderLength, it always runs, and a maxLength below 1 disables it.
+func checkSubjectLength(subject types.String, maxLength int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if maxLength < 1 || subject.IsUnknown() || subject.IsNull() {
+		return diags
+	}
+
+	if length := textLength(subject.ValueString()); length > maxLength {
+		diags.AddAttributeWarning(
+			path.Root("subject"),
+			"Subject Will Be Truncated",
+			fmt.Sprintf("subject is %d characters long, more than the %d email clients show in the inbox before truncating the rest. "+
+				"Shorten it, or raise the provider's subject_max_length.", length, maxLength),
+		)
+	}
+
+	return diags
+}
+
+// textLength returns the length of s in characters as readers see them,
+// that is grapheme clusters, so an emoji made of several code points, such
+// as a family emoji joined by zero-width joiners or a flag, counts as one.
+func textLength(s string) int {
+	length, err := textseg.TokenCount([]byte(s), textseg.ScanGraphemeClusters)
+	if err != nil {
+		return utf8.RuneCountInString(s)
+	}
+
+	return length
+}
+
+// emailAddress returns the lower-cased address of a value such as
+// "Name <user@example.com>", or the trimmed value when it does not parse.
+func emailAddress(raw string) string {
+	if addr, err := mail.ParseAddress(raw); err == nil {
+		return strings.ToLower(addr.Address)
+	}
+
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+// addressDomain returns the domain of an address as returned by emailAddress,
+// or "" when it has none.
+func addressDomain(address string) string {
+	_, domain, _ := strings.Cut(address, "@")
+
+	return domain
+}
+
+func isNoReplyAddress(address string) bool {
+	local, _, _ := strings.Cut(address, "@")
+
+	return noReplyLocalPart.MatchString(local)
+}
@@ -0,0 +1,475 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestLintEmailTemplate(t *testing.T) {
+	cases := map[string]struct {
+		from     types.String
+		replyTo  types.String
+		warnings []string
+	}{
+		"distinct addresses": {
+			from:    types.StringValue("Sender <noreply@example.com>"),
+			replyTo: types.StringValue("support@example.com"),
+		},
+		"reply_to unset": {
+			from:    types.StringValue("noreply@example.com"),
+			replyTo: types.StringNull(),
+		},
+		"reply_to unknown": {
+			from:    types.StringValue("noreply@example.com"),
+			replyTo: types.StringUnknown(),
+		},
+		"reply_to equals from": {
+			from:     types.StringValue("Sender <Support@Example.com>"),
+			replyTo:  types.StringValue("support@example.com"),
+			warnings: []string{"Redundant Reply-To Address"},
+		},
+		"both no-reply": {
+			from:     types.StringValue("Sender <no-reply@example.com>"),
+			replyTo:  types.StringValue("do_not_reply@example.com"),
+			warnings: []string{"Replies Cannot Be Received"},
+		},
+		"same no-reply address": {
+			from:     types.StringValue("noreply@example.com"),
+			replyTo:  types.StringValue("noreply@example.com"),
+			warnings: []string{"Redundant Reply-To Address", "Replies Cannot Be Received"},
+		},
+		"only from is no-reply": {
+			from:    types.StringValue("noreply@example.com"),
+			replyTo: types.StringValue("help@example.com"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := lintEmailTemplate(EmailTemplateResourceModel{From: tc.from, ReplyTo: tc.replyTo})
+
+			if len(diags) != len(tc.warnings) {
+				t.Fatalf("got %d diagnostics %v, want %v", len(diags), diags, tc.warnings)
+			}
+			for i, d := range diags {
+				if d.Summary() != tc.warnings[i] {
+					t.Errorf("diagnostic %d = %q, want %q", i, d.Summary(), tc.warnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_LintOnlyWhenEnabled(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"from":     tfString("noreply@example.com"),
+		"reply_to": tfString("noreply@example.com"),
+	})
+
+	for _, lint := range []bool{false, true} {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, map[string]tftypes.Value{"lint": tfBool(lint)})
+		r := h.resource("pocinfobipemails_email_template")
+
+		diags := r.apply(config)
+		if hasError(diags) {
+			t.Fatalf("lint=%t apply: %s", lint, diagnosticsString(diags))
+		}
+
+		warnings := 0
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityWarning {
+				warnings++
+			}
+		}
+		if lint && warnings == 0 {
+			t.Errorf("lint=true: expected warnings")
+		}
+		if !lint && warnings != 0 {
+			t.Errorf("lint=false: unexpected warnings: %s", diagnosticsString(diags))
+		}
+	}
+}
+
+func TestCheckPreheaderLength(t *testing.T) {
+	cases := map[string]struct {
+		preheader types.String
+		maxLength int
+		warn      bool
+	}{
+		"at threshold": {
+			preheader: types.StringValue(strings.Repeat("a", 150)),
+			maxLength: 150,
+		},
+		"beyond threshold": {
+			preheader: types.StringValue(strings.Repeat("a", 151)),
+			maxLength: 150,
+			warn:      true,
+		},
+		"multi-byte characters at threshold": {
+			preheader: types.StringValue(strings.Repeat("é", 150)),
+			maxLength: 150,
+		},
+		"custom threshold": {
+			preheader: types.StringValue(strings.Repeat("a", 41)),
+			maxLength: 40,
+			warn:      true,
+		},
+		"null": {
+			preheader: types.StringNull(),
+			maxLength: 150,
+		},
+		"unknown": {
+			preheader: types.StringUnknown(),
+			maxLength: 150,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := checkPreheaderLength(tc.preheader, tc.maxLength)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tc.warn {
+				t.Errorf("warned = %t, want %t: %v", got, tc.warn, diags)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_PreheaderMaxLength(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"preheader": tfString(strings.Repeat("a", 60)),
+	})
+
+	cases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		warn           bool
+	}{
+		"default": {},
+		"lowered": {
+			providerConfig: map[string]tftypes.Value{"preheader_max_length": tftypes.NewValue(tftypes.Number, 50)},
+			warn:           true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, tc.providerConfig)
+			r := h.resource("pocinfobipemails_email_template")
+
+			diags := r.apply(config)
+			if hasError(diags) {
+				t.Fatalf("apply: %s", diagnosticsString(diags))
+			}
+
+			warned := strings.Contains(diagnosticsString(diags), "Preheader Will Be Truncated")
+			if warned != tc.warn {
+				t.Errorf("warned = %t, want %t: %s", warned, tc.warn, diagnosticsString(diags))
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_InvalidPreheaderMaxLength(t *testing.T) {
+	mock := newMockInfobip(t)
+
+	_, diags := configureTestHarness(t, mock, map[string]tftypes.Value{
+		"preheader_max_length": tftypes.NewValue(tftypes.Number, 0),
+	})
+	if !hasError(diags) {
+		t.Fatalf("expected an error for preheader_max_length = 0")
+	}
+}
+
+func TestCheckTextLength(t *testing.T) {
+	// A family emoji is four people joined by zero-width joiners: seven
+	// code points and 25 bytes, but a single character to readers.
+	family := "\U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466"
+	flag := "\U0001F1FA\U0001F1E6"
+
+	cases := map[string]struct {
+		value string
+		warn  bool
+	}{
+		"family emoji at the limit":         {value: strings.Repeat(family, 10)},
+		"family emoji above the limit":      {value: strings.Repeat(family, 11), warn: true},
+		"flags and text at the limit":       {value: "Sale " + strings.Repeat(flag, 5)},
+		"combining accents at the limit":    {value: strings.Repeat("e\u0301", 10)},
+		"combining accents above the limit": {value: strings.Repeat("e\u0301", 11), warn: true},
+	}
+
+	checks := map[string]func(types.String, int) diag.Diagnostics{
+		"Preheader Will Be Truncated": checkPreheaderLength,
+		"Subject Will Be Truncated":   checkSubjectLength,
+	}
+
+	for name, tc := range cases {
+		for summary, check := range checks {
+			t.Run(name+"/"+summary, func(t *testing.T) {
+				diags := check(types.StringValue(tc.value), 10)
+				warned := diags.WarningsCount() > 0
+				if warned != tc.warn {
+					t.Fatalf("warned = %t, want %t: %v", warned, tc.warn, diags)
+				}
+				if warned && diags[0].Summary() != summary {
+					t.Errorf("summary = %q, want %q", diags[0].Summary(), summary)
+				}
+			})
+		}
+	}
+}
+
+func TestEmailTemplateResource_SubjectMaxLength(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"subject": tfString("Welcome \U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466"),
+	})
+
+	cases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		warn           bool
+	}{
+		"unset": {},
+		"emoji counts as one": {
+			providerConfig: map[string]tftypes.Value{"subject_max_length": tftypes.NewValue(tftypes.Number, 9)},
+		},
+		"lowered": {
+			providerConfig: map[string]tftypes.Value{"subject_max_length": tftypes.NewValue(tftypes.Number, 8)},
+			warn:           true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, tc.providerConfig)
+			r := h.resource("pocinfobipemails_email_template")
+
+			diags := r.apply(config)
+			if hasError(diags) {
+				t.Fatalf("apply: %s", diagnosticsString(diags))
+			}
+
+			warned := strings.Contains(diagnosticsString(diags), "Subject Will Be Truncated")
+			if warned != tc.warn {
+				t.Errorf("warned = %t, want %t: %s", warned, tc.warn, diagnosticsString(diags))
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_InvalidSubjectMaxLength(t *testing.T) {
+	mock := newMockInfobip(t)
+
+	_, diags := configureTestHarness(t, mock, map[string]tftypes.Value{
+		"subject_max_length": tftypes.NewValue(tftypes.Number, 0),
+	})
+	if !hasError(diags) {
+		t.Fatalf("expected an error for subject_max_length = 0")
+	}
+}
+
+func TestCheckHTMLWellFormed(t *testing.T) {
+	cases := map[string]struct {
+		html   string
+		errors []string
+	}{
+		"balanced": {
+			html: `<html><body><div><p>Hello</p></div></body></html>`,
+		},
+		"void and self-closing elements": {
+			html: `<div>Hello<br>there<img src="a.png"><hr/><span/></div>`,
+		},
+		"optional end tags": {
+			html: `<html><body><ul><li>One<li>Two</ul><table><tr><td>A<td>B</table><p>Bye`,
+		},
+		"unclosed div": {
+			html:   `<div><p>Hello</p>`,
+			errors: []string{"tag <div> is not closed"},
+		},
+		"unclosed inside closed parent": {
+			html:   `<td><span>Hello</td>`,
+			errors: []string{"tag <span> is not closed"},
+		},
+		"stray closing tag": {
+			html:   `<div>Hello</span></div>`,
+			errors: []string{"closing tag </span> has no matching opening tag"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := checkHTMLWellFormed(EmailTemplateResourceModel{Html: types.StringValue(tc.html)})
+
+			if len(diags) != len(tc.errors) {
+				t.Fatalf("got %d diagnostics %v, want %v", len(diags), diags, tc.errors)
+			}
+			for i, d := range diags {
+				if !strings.HasPrefix(d.Detail(), tc.errors[i]) {
+					t.Errorf("diagnostic %d = %q, want %q", i, d.Detail(), tc.errors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_StrictHTML(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString("<div><p>Hello</p>")})
+
+	for _, strict := range []bool{false, true} {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, map[string]tftypes.Value{"lint": tfBool(true), "strict_html": tfBool(strict)})
+		r := h.resource("pocinfobipemails_email_template")
+
+		diags := r.apply(config)
+		if hasError(diags) != strict {
+			t.Fatalf("strict_html=%t: diagnostics = %s", strict, diagnosticsString(diags))
+		}
+		if got := strings.Count(diagnosticsString(diags), "tag <div> is not closed"); got != 1 {
+			t.Errorf("strict_html=%t: unclosed div reported %d times, want once: %s", strict, got, diagnosticsString(diags))
+		}
+		if strict && len(mock.templates) != 0 {
+			t.Errorf("strict_html=true: template was created")
+		}
+	}
+}
+
+func TestCheckDomainAlignment(t *testing.T) {
+	cases := map[string]struct {
+		from    types.String
+		replyTo types.String
+		warn    bool
+	}{
+		"same domain": {
+			from:    types.StringValue("news@example.com"),
+			replyTo: types.StringValue("support@example.com"),
+		},
+		"same domain with display names": {
+			from:    types.StringValue(`"Acme News" <news@Example.com>`),
+			replyTo: types.StringValue("Acme Support <support@example.COM>"),
+		},
+		"different domains": {
+			from:    types.StringValue("news@example.com"),
+			replyTo: types.StringValue("support@example.org"),
+			warn:    true,
+		},
+		"different domains with display names": {
+			from:    types.StringValue("Acme <news@example.com>"),
+			replyTo: types.StringValue("Acme <support@example-mail.com>"),
+			warn:    true,
+		},
+		"reply_to unset": {
+			from:    types.StringValue("news@example.com"),
+			replyTo: types.StringNull(),
+		},
+		"reply_to unknown": {
+			from:    types.StringValue("news@example.com"),
+			replyTo: types.StringUnknown(),
+		},
+		"reply_to without domain": {
+			from:    types.StringValue("news@example.com"),
+			replyTo: types.StringValue("support"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := checkDomainAlignment(EmailTemplateResourceModel{From: tc.from, ReplyTo: tc.replyTo})
+
+			if diags.HasError() {
+				t.Fatalf("got errors %v, want at most a warning", diags)
+			}
+			if got := len(diags) == 1 && diags[0].Summary() == "Misaligned Reply-To Domain"; got != tc.warn || len(diags) > 1 {
+				t.Errorf("diagnostics = %v, want warning %t", diags, tc.warn)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_LintDomainAlignment(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"from":     tfString("Acme <news@example.com>"),
+		"reply_to": tfString("support@example.org"),
+	})
+
+	for _, enabled := range []bool{false, true} {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, map[string]tftypes.Value{"lint_domain_alignment": tfBool(enabled)})
+		r := h.resource("pocinfobipemails_email_template")
+
+		diags := r.apply(config)
+		if hasError(diags) {
+			t.Fatalf("lint_domain_alignment=%t apply: %s", enabled, diagnosticsString(diags))
+		}
+		if got := strings.Contains(diagnosticsString(diags), "Misaligned Reply-To Domain"); got != enabled {
+			t.Errorf("lint_domain_alignment=%t: diagnostics = %s", enabled, diagnosticsString(diags))
+		}
+	}
+}
+
+func TestCheckAMP(t *testing.T) {
+	cases := map[string]struct {
+		html string
+		warn bool
+	}{
+		"plain": {
+			html: `<html><body><img src="logo.png"><p>Amplify your sales</p></body></html>`,
+		},
+		"lightning attribute": {
+			html: `<!doctype html><html ⚡4email data-css-strict><body>Hi</body></html>`,
+			warn: true,
+		},
+		"amp4email attribute": {
+			html: `<html lang="en" AMP4EMAIL><body>Hi</body></html>`,
+			warn: true,
+		},
+		"amp component": {
+			html: `<html><body><amp-img src="logo.png" width="100" height="40"></amp-img></body></html>`,
+			warn: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := checkAMP(EmailTemplateResourceModel{Html: types.StringValue(tc.html)})
+
+			if diags.HasError() {
+				t.Fatalf("got errors %v, want at most a warning", diags)
+			}
+			if got := len(diags) == 1 && diags[0].Summary() == "AMP for Email Markup Detected"; got != tc.warn || len(diags) > 1 {
+				t.Errorf("diagnostics = %v, want warning %t", diags, tc.warn)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_DetectAMP(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"html": tfString(`<html ⚡4email><body><amp-img src="logo.png" width="100" height="40"></amp-img></body></html>`),
+	})
+
+	for _, enabled := range []bool{false, true} {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, map[string]tftypes.Value{"detect_amp": tfBool(enabled)})
+		r := h.resource("pocinfobipemails_email_template")
+
+		diags := r.apply(config)
+		if hasError(diags) {
+			t.Fatalf("detect_amp=%t apply: %s", enabled, diagnosticsString(diags))
+		}
+		if got := strings.Contains(diagnosticsString(diags), "AMP for Email Markup Detected"); got != enabled {
+			t.Errorf("detect_amp=%t: diagnostics = %s", enabled, diagnosticsString(diags))
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+)
+
+// keyedMutex hands out one mutex per key, so that operations on the same
+// key are serialized while different keys proceed in parallel.
+type keyedMutex struct {
+	locks sync.Map
+}
+
+// Lock locks the mutex for key and returns its unlock function.
+func (k *keyedMutex) Lock(key string) func() {
+	value, _ := k.locks.LoadOrStore(key, &sync.Mutex{})
+	mu, _ := value.(*sync.Mutex)
+
+	mu.Lock()
+
+	return mu.Unlock
+}
+
+// listEmailTemplates returns the list summary of every template of the
+// account. It stops with the context's error as soon as auth is cancelled,
+// without requesting further pages.
+func listEmailTemplates(auth context.Context, client *api.APIClient) ([]email.EmailTemplateListItem, error) {
+	var items []email.EmailTemplateListItem
+
+	for page := int32(0); ; page++ {
+		if err := auth.Err(); err != nil {
+			return nil, err
+		}
+
+		apiResponse, _, err := client.
+			EmailAPI.
+			GetAllEmailTemplates(auth).
+			Page(page).
+			Size(emailTemplatesPageSize).
+			Execute()
+		if err != nil {
+			return nil, fmt.Errorf("listing email templates: %w", err)
+		}
+		if apiResponse == nil {
+			return items, nil
+		}
+
+		items = append(items, apiResponse.Results...)
+
+		if apiResponse.Paging == nil || apiResponse.Paging.TotalPages == nil || page+1 >= *apiResponse.Paging.TotalPages {
+			return items, nil
+		}
+	}
+}
+
+// findEmailTemplatesByName lists every template of the account and returns
+// those whose name matches exactly.
+func findEmailTemplatesByName(auth context.Context, client *api.APIClient, name string) ([]email.EmailTemplateListItem, error) {
+	items, err := listEmailTemplates(auth, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []email.EmailTemplateListItem
+	for _, item := range items {
+		if item.GetName() == name {
+			matches = append(matches, item)
+		}
+	}
+
+	return matches, nil
+}
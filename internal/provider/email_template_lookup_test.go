@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip"
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+)
+
+func TestListEmailTemplates_Pagination(t *testing.T) {
+	mock := newMockInfobip(t)
+	for i := 0; i < 2*emailTemplatesPageSize+50; i++ {
+		mock.addTemplate(email.CreateEmailTemplateResponse{Name: "tmpl"})
+	}
+
+	items, err := listEmailTemplates(infobipAuthContext(context.Background(), "test-key", defaultAuthSchemeKey, ""), testAPIClient(mock))
+	if err != nil {
+		t.Fatalf("list: %s", err)
+	}
+	if len(items) != 2*emailTemplatesPageSize+50 {
+		t.Errorf("listed %d templates, want %d", len(items), 2*emailTemplatesPageSize+50)
+	}
+	if got := countRequests(mock, "GET /email/1/templates"); got != 3 {
+		t.Errorf("list requests = %d, want 3", got)
+	}
+}
+
+func TestListEmailTemplates_CancelledMidPagination(t *testing.T) {
+	mock := newMockInfobip(t)
+	for i := 0; i < 2*emailTemplatesPageSize+50; i++ {
+		mock.addTemplate(email.CreateEmailTemplateResponse{Name: "tmpl"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Cancel while the first page is being served.
+	mock.onRequest = func(*http.Request) { cancel() }
+
+	_, err := listEmailTemplates(infobipAuthContext(ctx, "test-key", defaultAuthSchemeKey, ""), testAPIClient(mock))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if got := countRequests(mock, "GET /email/1/templates"); got != 1 {
+		t.Errorf("list requests = %d, want 1", got)
+	}
+}
+
+// testAPIClient returns an Infobip client for the mock.
+func testAPIClient(mock *mockInfobip) *api.APIClient {
+	configuration := infobip.NewConfiguration()
+	configuration.Host = mock.host()
+	configuration.HTTPClient = mock.Client()
+
+	return api.NewAPIClient(configuration)
+}
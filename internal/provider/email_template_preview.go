@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// previewPollInterval is the delay before the first poll for the preview
+	// image. It doubles after every poll, up to previewPollMaxInterval.
+	previewPollInterval    = time.Second
+	previewPollMaxInterval = 10 * time.Second
+	// previewWaitTimeout bounds the time spent waiting for the preview image.
+	previewWaitTimeout = 2 * time.Minute
+)
+
+// waitForPreview polls the template until Infobip has generated its preview
+// image, which happens asynchronously after create, and returns its URL. It
+// returns "" when the URL is not populated within previewWaitTimeout or auth
+// is cancelled. Failed polls are retried until then.
+func (r *EmailTemplateResource) waitForPreview(ctx, auth context.Context, id int64) string {
+	auth, cancel := context.WithTimeout(auth, previewWaitTimeout)
+	defer cancel()
+
+	interval := previewPollInterval
+	for {
+		select {
+		case <-auth.Done():
+			return ""
+		case <-time.After(interval):
+		}
+
+		emailTemplate, _, err := r.infobipClient.
+			EmailAPI.
+			GetEmailTemplate(auth).
+			ID(id).
+			Execute()
+		if err != nil {
+			tflog.Debug(ctx, "Polling for the email template preview failed", map[string]any{"id": id, "error": err.Error()})
+		} else if emailTemplate.ImagePreviewURL != "" {
+			return emailTemplate.ImagePreviewURL
+		}
+
+		interval = min(interval*2, previewPollMaxInterval)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fastPreviewPolling shortens the preview polling delays for a test.
+func fastPreviewPolling(t *testing.T, timeout time.Duration) {
+	interval, maxInterval, waitTimeout := previewPollInterval, previewPollMaxInterval, previewWaitTimeout
+	previewPollInterval, previewPollMaxInterval, previewWaitTimeout = time.Millisecond, 4*time.Millisecond, timeout
+	t.Cleanup(func() {
+		previewPollInterval, previewPollMaxInterval, previewWaitTimeout = interval, maxInterval, waitTimeout
+	})
+}
+
+func TestEmailTemplateResource_WaitForPreview(t *testing.T) {
+	fastPreviewPolling(t, time.Minute)
+
+	mock := newMockInfobip(t)
+	mock.previewAfterGets = 3
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"wait_for_preview": tfBool(true)}))
+	if hasError(diags) || len(diags) != 0 {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("image_preview_url"); got != "https://preview.example.com/1.png" {
+		t.Errorf("image_preview_url = %q, want the populated URL", got)
+	}
+	if got := mock.getCounts[1]; got != 3 {
+		t.Errorf("polled %d times, want 3", got)
+	}
+}
+
+func TestEmailTemplateResource_WaitForPreviewTimeout(t *testing.T) {
+	fastPreviewPolling(t, 20*time.Millisecond)
+
+	mock := newMockInfobip(t)
+	mock.previewAfterGets = 1000
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"wait_for_preview": tfBool(true)}))
+	if hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if !strings.Contains(diagnosticsString(diags), "Preview Image Not Ready") {
+		t.Errorf("diagnostics = %s, want a preview warning", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("image_preview_url"); got != "" {
+		t.Errorf("image_preview_url = %q, want empty", got)
+	}
+	if mock.getCounts[1] == 0 {
+		t.Errorf("the template was never polled")
+	}
+}
@@ -5,17 +5,22 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
-	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip"
 	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -23,6 +28,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &EmailTemplateResource{}
 var _ resource.ResourceWithImportState = &EmailTemplateResource{}
+var _ resource.ResourceWithModifyPlan = &EmailTemplateResource{}
+var _ resource.ResourceWithValidateConfig = &EmailTemplateResource{}
 
 func NewEmailTemplateResource() resource.Resource {
 	return &EmailTemplateResource{}
@@ -32,22 +39,108 @@ func NewEmailTemplateResource() resource.Resource {
 type EmailTemplateResource struct {
 	infobipClient *api.APIClient
 	apiKey        string
+	authSchemeKey string
+	lint          bool
+	// lintDomainAlignment warns when the from and reply_to domains differ.
+	lintDomainAlignment bool
+	// detectAMP warns when the html contains AMP for Email markup.
+	detectAMP bool
+	// allowedFromDomains, when set, are the only allowed from domains.
+	allowedFromDomains []string
+	// strictHTML makes unbalanced html tags a plan error.
+	strictHTML bool
+	// externalLinter, when set, checks the planned html.
+	externalLinter *externalLinter
+	// mxChecker, when set, checks the MX records of the from domain.
+	mxChecker *mxChecker
+	// preheaderMaxLength is the preheader length above which ModifyPlan warns.
+	preheaderMaxLength int
+	// subjectMaxLength is the subject length above which ModifyPlan warns.
+	subjectMaxLength int
+	// minifyHTML minifies html before it is sent.
+	minifyHTML bool
+	// ignorePreheaderWhitespace and ignoreReplyToWhitespace suppress
+	// whitespace-only diffs of those fields.
+	ignorePreheaderWhitespace bool
+	ignoreReplyToWhitespace   bool
+	// normalizeUnicode suppresses diffs of the subject, preheader and from
+	// that only differ by Unicode composition.
+	normalizeUnicode bool
+	// whitespaceOnlyAsNull treats a whitespace-only subject or preheader as
+	// empty.
+	whitespaceOnlyAsNull bool
+	// inlineRemoteImages inlines remote images before html is sent, using
+	// httpClient to fetch them.
+	inlineRemoteImages bool
+	httpClient         *http.Client
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+	// deleteGuard refuses unconfirmed bulk deletes.
+	deleteGuard *deleteGuard
+	// deadline stops API calls once the global deadline has passed.
+	deadline *apiDeadline
+	// templateNameLocks serializes create_if_missing lookups per name.
+	templateNameLocks *keyedMutex
+	// managedIDs detects templates read by more than one resource.
+	managedIDs *managedIDTracker
+	// timestamps formats the created_at and updated_at the provider sets.
+	timestamps timestampFormatter
+	// compareLiveHTML compares the planned html with the live template html.
+	compareLiveHTML bool
+	// version is the provider version stored in provider_version.
+	version string
+	// placeholderSyntax matches the placeholders of the html.
+	placeholderSyntax *regexp.Regexp
+	// fromOverrides rewrite the configured from before it is sent.
+	fromOverrides fromOverrides
 }
 
 // EmailTemplateResourceModel describes the resource data model.
 type EmailTemplateResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	From            types.String `tfsdk:"from"`
-	ReplyTo         types.String `tfsdk:"reply_to"`
-	Subject         types.String `tfsdk:"subject"`
-	Preheader       types.String `tfsdk:"preheader"`
-	Html            types.String `tfsdk:"html"`
-	IsHtmlEditable  types.Bool   `tfsdk:"is_html_editable"`
-	LandingPage     types.String `tfsdk:"landing_page"`
-	ImagePreviewUrl types.String `tfsdk:"image_preview_url"`
-	CreatedAt       types.String `tfsdk:"created_at"`
-	UpdatedAt       types.String `tfsdk:"updated_at"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	From               types.String `tfsdk:"from"`
+	EffectiveFrom      types.String `tfsdk:"effective_from"`
+	ReplyTo            types.String `tfsdk:"reply_to"`
+	Subject            types.String `tfsdk:"subject"`
+	Preheader          types.String `tfsdk:"preheader"`
+	PreheaderSegments  types.List   `tfsdk:"preheader_segments"`
+	EffectivePreheader types.String `tfsdk:"effective_preheader"`
+	Html               types.String `tfsdk:"html"`
+	HtmlRaw            types.String `tfsdk:"html_raw"`
+	ParentID           types.String `tfsdk:"parent_id"`
+	ResolvedHtml       types.String `tfsdk:"resolved_html"`
+	AssetBaseUrl       types.String `tfsdk:"asset_base_url"`
+	HtmlChecksum       types.String `tfsdk:"html_checksum"`
+	SendNormalizedHtml types.Bool   `tfsdk:"send_normalized_html"`
+	ExposeRawJson      types.Bool   `tfsdk:"expose_raw_json"`
+	RawJson            types.String `tfsdk:"raw_json"`
+	IsHtmlEditable     types.Bool   `tfsdk:"is_html_editable"`
+	LandingPage        types.String `tfsdk:"landing_page"`
+	ImagePreviewUrl    types.String `tfsdk:"image_preview_url"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	UpdatedAt          types.String `tfsdk:"updated_at"`
+	Placeholders       types.Set    `tfsdk:"placeholders"`
+	HtmlSummary        types.String `tfsdk:"html_summary"`
+	ProviderVersion    types.String `tfsdk:"provider_version"`
+	Etag               types.String `tfsdk:"etag"`
+
+	RecreateOnEditorSwitch  types.Bool `tfsdk:"recreate_on_editor_switch"`
+	CreateIfMissing         types.Bool `tfsdk:"create_if_missing"`
+	RollbackOnUpdateFailure types.Bool `tfsdk:"rollback_on_update_failure"`
+	WaitForPreview          types.Bool `tfsdk:"wait_for_preview"`
+	MergeUnmanagedFields    types.Bool `tfsdk:"merge_unmanaged_fields"`
+	SubjectNormalize        types.Bool `tfsdk:"subject_normalize"`
+	RequestHeaders          types.Map  `tfsdk:"request_headers"`
 }
 
 func (r *EmailTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,10 +150,14 @@ func (r *EmailTemplateResource) Metadata(ctx context.Context, req resource.Metad
 func (r *EmailTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages an Infobip Email Template resource.",
+		Version:     emailTemplateSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Unique identifier of the email template.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "Name of the email template.",
@@ -70,52 +167,191 @@ func (r *EmailTemplateResource) Schema(ctx context.Context, req resource.SchemaR
 				Description: "Sender email address used in the template.",
 				Required:    true,
 			},
+			"effective_from": schema.StringAttribute{
+				Description: "The sender email address as stored by Infobip: `from`, rewritten by the provider's `from_overrides`.",
+				Computed:    true,
+			},
 			"reply_to": schema.StringAttribute{
 				Description: "Reply-to email address for the template.",
 				Optional:    true,
 			},
 			"subject": schema.StringAttribute{
-				Description: "Subject line of the email template.",
+				Description: "Subject line of the email template. Must not be empty.",
 				Required:    true,
+				Validators: []validator.String{
+					emptySubjectValidator{},
+				},
 			},
 			"preheader": schema.StringAttribute{
 				Description: "Preheader text shown in email previews (optional).",
 				Optional:    true,
 			},
+			"preheader_segments": schema.ListAttribute{
+				Description: "Preheader split into segments, as an alternative to `preheader`. Infobip stores a single preheader, " +
+					"so the segments are trimmed and joined with a space, and a warning is emitted when there is more than one.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"effective_preheader": schema.StringAttribute{
+				Description: "The preheader as stored by Infobip: `preheader`, or the joined `preheader_segments`.",
+				Computed:    true,
+			},
 			"html": schema.StringAttribute{
-				Description: "HTML content of the email template.",
+				Description: "HTML content of the email template. Must not contain control characters other than tab, newline and carriage return.",
 				Required:    true,
+				Validators: []validator.String{
+					htmlControlCharactersValidator{},
+				},
 				PlanModifiers: []planmodifier.String{
 					htmlWhitespaceInsensitiveModifier{},
 				},
 			},
+			"html_raw": schema.StringAttribute{
+				Description: "The html exactly as last sent to Infobip, without normalization. Whitespace-only changes to html do not change it.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					htmlRawModifier{},
+				},
+			},
+			"parent_id": schema.StringAttribute{
+				Description: "ID of an Infobip template used as a base layout. Its html declares named slots as `<!-- slot:name -->default<!-- /slot:name -->`, " +
+					"and this template's html fills them with slot blocks of the same form; html without slot blocks fills the `content` slot. " +
+					"Slots that are not filled keep the parent's content. The composed html is sent to Infobip, and changes to the parent html are planned as changes to this template.",
+				Optional: true,
+			},
+			"resolved_html": schema.StringAttribute{
+				Description: "The html composed from the `parent_id` template and `html`, as sent to Infobip before minification. Unset without `parent_id`.",
+				Computed:    true,
+			},
+			"asset_base_url": schema.StringAttribute{
+				Description: "Absolute http or https URL that relative `src`, `href` and `background` URLs in the html are resolved against before it is sent, " +
+					"such as `https://cdn.example.com/emails/`. Absolute URLs, URLs with a scheme such as `mailto:` or `tel:`, anchors and placeholders are kept. " +
+					"`html_raw` holds the rewritten html.",
+				Optional: true,
+			},
+			"html_checksum": schema.StringAttribute{
+				Description: "Expected sha256 digest of `html` as configured, in hex and optionally prefixed with `sha256:`. " +
+					"Creates and updates fail when the html does not match, such as when it is loaded from the wrong file.",
+				Optional: true,
+			},
+			"send_normalized_html": schema.BoolAttribute{
+				Description: "Send the html to Infobip with line endings normalized, whitespace collapsed and whitespace between tags removed, " +
+					"the form html is compared in, instead of as configured. `html_raw` holds the normalized html. Defaults to `false`.",
+				Optional: true,
+			},
+			"expose_raw_json": schema.BoolAttribute{
+				Description: "Store the JSON body of the last Infobip create, read or update response in `raw_json`, to debug how it maps to attributes.",
+				Optional:    true,
+			},
+			"raw_json": schema.StringAttribute{
+				Description: "The JSON body of the last Infobip create, read or update response of the template, as received. Unset unless `expose_raw_json` is set.",
+				Computed:    true,
+				Sensitive:   true,
+			},
 			"is_html_editable": schema.BoolAttribute{
 				Description: "Indicates whether the HTML content can be edited in Infobip UI.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"landing_page": schema.StringAttribute{
-				Description: "Associated landing page ID, if any.",
+				Description: "Associated landing page ID, if any. Removing a previously configured value disassociates the landing page.",
 				Optional:    true,
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					landingPageClearModifier{},
+				},
 			},
 			"image_preview_url": schema.StringAttribute{
 				Description: "URL of the email template’s image preview.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Description: "Timestamp when the email template was created (RFC3339 format).",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"updated_at": schema.StringAttribute{
 				Description: "Timestamp when the email template was last updated (RFC3339 format).",
 				Computed:    true,
 			},
+			"etag": schema.StringAttribute{
+				Description: "ETag of the template as last read or written, if Infobip sends one. Updates are made conditional on it, " +
+					"so they fail instead of overwriting edits made, such as in the Infobip UI, since the last refresh. " +
+					"Without an ETag, `updated_at` is compared instead. Such edits block the apply until a refresh, such as the next plan, reads them.",
+				Computed: true,
+			},
+			"html_summary": schema.StringAttribute{
+				Description: "Line count, byte count and shortened sha256 digest of `html`, such as `1250 lines, 51234 bytes, sha256:9b21c0de4f3a`. " +
+					"Plans show its change as a concise summary of an html change, next to the full html diff.",
+				Computed: true,
+			},
+			"placeholders": schema.SetAttribute{
+				Description: "Names of the merge placeholders used in the html, such as `first_name` for `{{first_name}}`. Placeholders escaped with a backslash are ignored.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"provider_version": schema.StringAttribute{
+				Description: "Version of the provider that last created, updated or imported the template, to include in support requests. " +
+					"It only changes when the template is otherwise changed, so upgrading the provider does not cause a diff.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"recreate_on_editor_switch": schema.BoolAttribute{
+				Description: "Replace the template instead of updating it when an html change would switch it between the drag-and-drop and code editors, " +
+					"which the API may reject. A switch is detected when the template is not HTML editable (it was built in the drag-and-drop editor) " +
+					"and the html changes beyond whitespace.",
+				Optional: true,
+			},
+			"create_if_missing": schema.BoolAttribute{
+				Description: "On create, adopt an existing template with the same name instead of creating a new one, updating it to match the configuration. " +
+					"Fails if several templates share the name. Concurrent creates of the same name are serialized within one Terraform run only.",
+				Optional: true,
+			},
+			"rollback_on_update_failure": schema.BoolAttribute{
+				Description: "When an update fails, send the prior configuration again to restore the template before reporting the error, " +
+					"in case the failed update was partially applied. If the rollback fails too, both errors are reported.",
+				Optional: true,
+			},
+			"request_headers": schema.MapAttribute{
+				Description: "Headers added to the Infobip API requests of this template, such as `X-Infobip-Organization-Id` to manage it in another sub-account. " +
+					"They override the provider's `default_headers` and `organization_id`. " +
+					"The values of headers whose names contain `auth`, `cookie`, `key`, `password`, `secret`, `signature` or `token` are masked in provider logs and the trace file. " +
+					"Changing them alone makes no API call. Other headers the provider sets itself, such as `Authorization`, are rejected.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					headersValidator{allowOrganization: true},
+				},
+			},
+			"subject_normalize": schema.BoolAttribute{
+				Description: "Trim the subject and collapse repeated whitespace in it, such as double spaces, before it is planned and sent, " +
+					"so cosmetic whitespace does not reach recipients or cause diffs. Casing is kept as configured. Defaults to `false`.",
+				Optional: true,
+			},
+			"merge_unmanaged_fields": schema.BoolAttribute{
+				Description: "Keep the `reply_to` and `preheader` stored by Infobip, such as values set in the UI, when they are not configured, instead of clearing them on update. " +
+					"Fields that are not configured stay unset in state, and removing one from the configuration stops managing it rather than clearing it. Defaults to `false`.",
+				Optional: true,
+			},
+			"wait_for_preview": schema.BoolAttribute{
+				Description: "On create, wait up to 2 minutes for Infobip to generate the preview image, so `image_preview_url` is populated. " +
+					"If it is not ready in time, a warning is emitted and `image_preview_url` stays empty until a later refresh.",
+				Optional: true,
+			},
 		},
 	}
 }
 
 func (r *EmailTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	tflog.Info(ctx, "Configuring Infobip client")
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
@@ -131,13 +367,320 @@ func (r *EmailTemplateResource) Configure(ctx context.Context, req resource.Conf
 
 		return
 	}
+	ctx = correlateLogs(ctx, pd.correlationID)
+	tflog.Info(ctx, "Configuring Infobip client")
 
 	r.infobipClient = pd.client
 	r.apiKey = pd.apiKey
+	r.authSchemeKey = pd.authSchemeKey
+	r.lint = pd.lint
+	r.lintDomainAlignment = pd.lintDomainAlignment
+	r.detectAMP = pd.detectAMP
+	r.allowedFromDomains = pd.allowedFromDomains
+	r.logRedactPatterns = pd.logRedactPatterns
+	r.correlationID = pd.correlationID
+	r.organizationID = pd.organizationID
+	r.warningsAsErrors = pd.warningsAsErrors
+	r.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+	r.deleteGuard = pd.deleteGuard
+	r.deadline = pd.deadline
+	r.strictHTML = pd.strictHTML
+	r.externalLinter = pd.externalLinter
+	r.mxChecker = pd.mxChecker
+	r.preheaderMaxLength = pd.preheaderMaxLength
+	r.subjectMaxLength = pd.subjectMaxLength
+	r.minifyHTML = pd.minifyHTML
+	r.ignorePreheaderWhitespace = pd.ignorePreheaderWhitespace
+	r.ignoreReplyToWhitespace = pd.ignoreReplyToWhitespace
+	r.normalizeUnicode = pd.normalizeUnicode
+	r.whitespaceOnlyAsNull = pd.whitespaceOnlyAsNull
+	r.inlineRemoteImages = pd.inlineRemoteImages
+	r.httpClient = pd.httpClient
+	r.templateNameLocks = pd.templateNameLocks
+	r.managedIDs = pd.managedIDs
+	r.version = pd.version
+	r.compareLiveHTML = pd.compareLiveHTML
+	r.timestamps = pd.timestamps
+	r.placeholderSyntax = pd.placeholderSyntax
+	r.fromOverrides = pd.fromOverrides
 	tflog.Info(ctx, "Finish Infobip client configuration")
 }
 
+func (r *EmailTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config EmailTemplateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Preheader.IsNull() && !config.PreheaderSegments.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preheader_segments"),
+			"Conflicting Preheader Attributes",
+			"Only one of preheader and preheader_segments can be set.",
+		)
+	}
+
+	if !config.AssetBaseUrl.IsNull() && !config.AssetBaseUrl.IsUnknown() {
+		if _, err := parseAssetBaseURL(config.AssetBaseUrl.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("asset_base_url"),
+				"Invalid Asset Base URL",
+				"asset_base_url must be an absolute http or https URL: "+err.Error(),
+			)
+		}
+	}
+
+	if !config.HtmlChecksum.IsNull() && !config.HtmlChecksum.IsUnknown() {
+		if _, err := parseHTMLChecksum(config.HtmlChecksum.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("html_checksum"), "Invalid HTML Checksum", err.Error())
+		}
+	}
+}
+
+func (r *EmailTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	// Nothing to check when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan EmailTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = withRequestHeaders(ctx, plan.RequestHeaders)
+
+	// Lint warnings would repeat the strict html errors, and the plan fails
+	// anyway.
+	if r.strictHTML {
+		if diags := checkHTMLWellFormed(plan); diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+	if r.lint {
+		resp.Diagnostics.Append(lintEmailTemplate(plan)...)
+	}
+	if r.lintDomainAlignment {
+		resp.Diagnostics.Append(checkDomainAlignment(plan)...)
+	}
+	if r.detectAMP {
+		resp.Diagnostics.Append(checkAMP(plan)...)
+	}
+	resp.Diagnostics.Append(checkAllowedFromDomain(r.fromOverrides.apply(plan.From), r.allowedFromDomains)...)
+	resp.Diagnostics.Append(r.mxChecker.check(ctx, r.fromOverrides.apply(plan.From))...)
+	resp.Diagnostics.Append(checkSubjectLength(plan.Subject, r.subjectMaxLength)...)
+	resp.Diagnostics.Append(checkPreheaderLength(effectivePreheader(ctx, plan), r.preheaderMaxLength)...)
+	resp.Diagnostics.Append(checkPreheaderSegments(ctx, plan)...)
+
+	// A whitespace-only subject is as good as empty, and a whitespace-only
+	// preheader is not sent, if the provider opted in.
+	if r.whitespaceOnlyAsNull {
+		if isWhitespaceOnly(plan.Subject) {
+			resp.Diagnostics.Append(emptySubjectDiagnostic(path.Root("subject")))
+			return
+		}
+		if isWhitespaceOnly(plan.Preheader) {
+			plan.Preheader = types.StringValue("")
+		}
+	}
+
+	// Cosmetic whitespace is removed from the subject before it is planned
+	// and sent, if the template opted in.
+	if plan.SubjectNormalize.ValueBool() && !plan.Subject.IsUnknown() && !plan.Subject.IsNull() {
+		plan.Subject = types.StringValue(collapseWhitespace(plan.Subject.ValueString()))
+		if plan.Subject.ValueString() == "" {
+			resp.Diagnostics.Append(emptySubjectDiagnostic(path.Root("subject")))
+			return
+		}
+	}
+
+	var state EmailTemplateResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// With a parent template, the html fills the parent's slots, and the
+	// composed html is what is compared and sent.
+	resolved := plan.Html
+	plan.ResolvedHtml = types.StringNull()
+	if !plan.ParentID.IsNull() && !plan.Html.IsUnknown() && !plan.Html.IsNull() {
+		if !req.State.Raw.IsNull() && plan.ParentID.Equal(state.ID) {
+			resp.Diagnostics.AddAttributeError(path.Root("parent_id"), "Invalid Parent Template", "An email template cannot be its own parent.")
+			return
+		}
+
+		resolved = types.StringUnknown()
+		if !plan.ParentID.IsUnknown() {
+			resp.Diagnostics.Append(r.deadline.check("read parent email template ID " + plan.ParentID.String())...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			html, err := r.resolveHTML(infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID), plan)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("parent_id"), "Error Composing Email Template", err.Error())
+				return
+			}
+			resolved = types.StringValue(html)
+		}
+		plan.ResolvedHtml = resolved
+		if resolved.IsUnknown() {
+			plan.HtmlRaw = types.StringUnknown()
+		}
+	}
+
+	if r.externalLinter != nil && !resolved.IsUnknown() && !resolved.IsNull() {
+		diags := r.externalLinter.check(ctx, resolved.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	// The sent html depends on the asset base URL and on whether it is
+	// normalized.
+	if plan.AssetBaseUrl.IsUnknown() || plan.SendNormalizedHtml.IsUnknown() {
+		plan.HtmlRaw = types.StringUnknown()
+	}
+
+	if (r.minifyHTML || r.inlineRemoteImages || !plan.ParentID.IsNull() || !plan.AssetBaseUrl.IsNull() || plan.SendNormalizedHtml.ValueBool()) &&
+		!plan.AssetBaseUrl.IsUnknown() && !plan.SendNormalizedHtml.IsUnknown() && !resolved.IsUnknown() && !resolved.IsNull() {
+		if source, err := r.outgoingHTML(resolved.ValueString(), plan); err == nil {
+			// With inlined images, the stored html is compared as it was
+			// before inlining.
+			stateSource := state.Html.ValueString()
+			if r.inlineRemoteImages && !req.State.Raw.IsNull() {
+				value, diags := req.Private.GetKey(ctx, privateKeyHTMLSource)
+				resp.Diagnostics.Append(diags...)
+				if html, ok := htmlSourceFromPrivate(value); ok {
+					stateSource = html
+				}
+			}
+
+			// Compare the html as it would be sent, so html that minifies to
+			// the stored template does not diff against it.
+			if !req.State.Raw.IsNull() && normalizeHTML(source) == normalizeHTML(stateSource) {
+				plan.Html = state.Html
+				plan.HtmlRaw = state.HtmlRaw
+				if !plan.ResolvedHtml.IsNull() {
+					plan.ResolvedHtml = state.ResolvedHtml
+				}
+			} else if r.inlineRemoteImages {
+				// Only known once the images are fetched on apply.
+				plan.HtmlRaw = types.StringUnknown()
+			} else if !plan.HtmlRaw.IsUnknown() {
+				plan.HtmlRaw = types.StringValue(source)
+			}
+		}
+	}
+
+	// Keep the prior preheader and reply_to when they only differ by
+	// whitespace, if the provider opted in for that field.
+	if !req.State.Raw.IsNull() {
+		if r.ignorePreheaderWhitespace && whitespaceEqual(plan.Preheader, state.Preheader) {
+			plan.Preheader = state.Preheader
+		}
+		if r.ignoreReplyToWhitespace && whitespaceEqual(plan.ReplyTo, state.ReplyTo) {
+			plan.ReplyTo = state.ReplyTo
+		}
+	}
+
+	// Keep the prior subject, preheader and from when they only differ by
+	// Unicode composition, if the provider opted in. The values are sent as
+	// configured, so the stored value always matches the configuration it
+	// was applied from.
+	if !req.State.Raw.IsNull() && r.normalizeUnicode {
+		if nfcEqual(plan.Subject, state.Subject) {
+			plan.Subject = state.Subject
+		}
+		if nfcEqual(plan.Preheader, state.Preheader) {
+			plan.Preheader = state.Preheader
+		}
+		if nfcEqual(plan.From, state.From) {
+			plan.From = state.From
+		}
+	}
+
+	if !resolved.IsUnknown() && !resolved.IsNull() {
+		plan.Placeholders = placeholdersValue(normalizeHTML(resolved.ValueString()), r.placeholderSyntax)
+	}
+	plan.EffectiveFrom = r.fromOverrides.apply(plan.From)
+	plan.EffectivePreheader = effectivePreheader(ctx, plan)
+	// An unmanaged preheader is whatever Infobip stores.
+	if plan.MergeUnmanagedFields.ValueBool() && plan.EffectivePreheader.IsNull() {
+		plan.EffectivePreheader = types.StringUnknown()
+		if !req.State.Raw.IsNull() {
+			plan.EffectivePreheader = state.EffectivePreheader
+		}
+	}
+
+	plan.HtmlSummary = htmlSummaryValue(plan.Html)
+
+	// raw_json is only stored on request. Otherwise it keeps its state
+	// value until the next create, read or update replaces it.
+	if !plan.ExposeRawJson.IsUnknown() && !plan.ExposeRawJson.ValueBool() {
+		plan.RawJson = types.StringNull()
+	}
+
+	// The preview image and the editor mode follow the html, so they are
+	// only kept from state when the html does not change.
+	if !req.State.Raw.IsNull() && !(plan.Html.Equal(state.Html) && plan.HtmlRaw.Equal(state.HtmlRaw) && plan.ResolvedHtml.Equal(state.ResolvedHtml)) {
+		plan.ImagePreviewUrl = types.StringUnknown()
+		plan.IsHtmlEditable = types.BoolUnknown()
+	}
+
+	if req.State.Raw.IsNull() {
+		plan.ProviderVersion = types.StringValue(r.version)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to replace when creating
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// provider_version is kept from state, unless the template changes
+	// anyway and the update records the current version.
+	if !resp.Plan.Raw.Equal(req.State.Raw) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("provider_version"), r.version)...)
+	}
+
+	if r.compareLiveHTML {
+		resp.Diagnostics.Append(r.deadline.check("read email template ID " + state.ID.String())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+		resp.Diagnostics.Append(r.liveHTMLDiagnostics(ctx, auth, state, plan)...)
+	}
+
+	if plan.RecreateOnEditorSwitch.ValueBool() && isEditorSwitch(state, plan) {
+		tflog.Info(ctx, "Replacing email template to switch editor mode", map[string]any{"id": state.ID.ValueString()})
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("html"))
+	}
+}
+
 func (r *EmailTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
 	// Retrieve values from plan
 	var plan EmailTemplateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -145,24 +688,103 @@ func (r *EmailTemplateResource) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withRequestHeaders(ctx, plan.RequestHeaders)
+
+	resp.Diagnostics.Append(checkHTMLChecksum(ctx, req.Config, plan.HtmlChecksum)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Make API call to create resource
-	auth := context.WithValue(
-		context.Background(),
-		infobip.ContextAPIKeys,
-		map[string]infobip.APIKey{"APIKeyHeader": {Key: r.apiKey, Prefix: "App"}},
-	)
-	emailTemplate, httpResponse, err := r.infobipClient.
-		EmailAPI.
-		CreateEmailTemplate(auth).
-		Name(plan.Name.ValueString()).
-		From(plan.From.ValueString()).
-		ReplyTo(plan.ReplyTo.ValueString()).
-		Subject(plan.Subject.ValueString()).
-		Preheader(plan.Preheader.ValueString()).
-		Html(plan.Html.ValueString()).
-		LandingPage(plan.LandingPage.ValueString()).
-		Execute()
+	resp.Diagnostics.Append(r.deadline.check("create the email template")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+	// With create_if_missing, adopt a template with the same name instead of
+	// creating a duplicate. The name lock keeps concurrent creates of the same
+	// name in this provider from both missing it and creating it twice.
+	var adoptID int64
+	if plan.CreateIfMissing.ValueBool() {
+		unlock := r.templateNameLocks.Lock(plan.Name.ValueString())
+		defer unlock()
+
+		existing, err := findEmailTemplatesByName(auth, r.infobipClient, plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating Email Template",
+				"Could not check whether an email template named "+plan.Name.String()+" exists: "+err.Error(),
+			)
+			return
+		}
+
+		if len(existing) > 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Ambiguous Email Template Name",
+				fmt.Sprintf("create_if_missing found %d email templates named %s; rename them or import the intended one instead.", len(existing), plan.Name.String()),
+			)
+			return
+		}
+
+		if len(existing) == 1 {
+			adoptID = existing[0].GetId()
+			tflog.Info(ctx, "Adopting existing email template", map[string]any{"id": adoptID, "name": plan.Name.ValueString()})
+		}
+	}
+
+	resolvedHTML, err := r.resolveHTML(auth, plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parent_id"), "Error Composing Email Template", err.Error())
+		return
+	}
+	sourceHTML, err := r.outgoingHTML(resolvedHTML, plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("html"),
+			"Error Preparing HTML",
+			"Could not prepare the email template html: "+err.Error(),
+		)
+		return
+	}
+	sentHTML := sourceHTML
+	if r.inlineRemoteImages {
+		sentHTML = r.inlineImages(ctx, sourceHTML, &resp.Diagnostics)
+	}
+
+	// Looking up the name and the parent may have taken the rest of the budget.
+	resp.Diagnostics.Append(r.deadline.check("create the email template")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var emailTemplate *email.CreateEmailTemplateResponse
+	var httpResponse *http.Response
+	if adoptID != 0 {
+		emailTemplate, httpResponse, err = r.infobipClient.
+			EmailAPI.
+			UpdateEmailTemplate(auth).
+			ID(adoptID).
+			Name(plan.Name.ValueString()).
+			From(r.fromOverrides.apply(plan.From).ValueString()).
+			ReplyTo(plan.ReplyTo.ValueString()).
+			Subject(plan.Subject.ValueString()).
+			Preheader(effectivePreheader(ctx, plan).ValueString()).
+			Html(sentHTML).
+			LandingPage(plan.LandingPage.ValueString()).
+			Execute()
+	} else {
+		emailTemplate, httpResponse, err = r.infobipClient.
+			EmailAPI.
+			CreateEmailTemplate(auth).
+			Name(plan.Name.ValueString()).
+			From(r.fromOverrides.apply(plan.From).ValueString()).
+			ReplyTo(plan.ReplyTo.ValueString()).
+			Subject(plan.Subject.ValueString()).
+			Preheader(effectivePreheader(ctx, plan).ValueString()).
+			Html(sentHTML).
+			LandingPage(plan.LandingPage.ValueString()).
+			Execute()
+	}
 
 	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
 	// Check for errors
@@ -171,22 +793,70 @@ func (r *EmailTemplateResource) Create(ctx context.Context, req resource.CreateR
 			"Error Creating Email Template",
 			"An error was encountered while creating the email template: "+err.Error(),
 		)
+		return
+	}
+
+	// Never persist an ID that Read and Delete could resolve to another template.
+	if isEmptyEmailTemplate(emailTemplate) {
+		resp.Diagnostics.AddError(
+			"Invalid Email Template ID",
+			fmt.Sprintf("Infobip reported success but returned template ID %d, which is not a valid ID. "+
+				"The template may have been created; check the Infobip account and import it if so.", emailTemplateID(emailTemplate)),
+		)
+		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
+	if plan.HtmlRaw.IsUnknown() {
+		plan.HtmlRaw = types.StringValue(sentHTML)
+	}
+	managed := plan
+	plan.RawJson = rawJSONValue(plan.ExposeRawJson, httpResponse)
+	plan.Etag = responseETag(httpResponse)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyAPIUpdatedAt, apiUpdatedAtValue(emailTemplate.UpdatedAt))...)
 	plan.ID = types.StringValue(fmt.Sprintf("%d", emailTemplate.ID))
 	plan.Name = types.StringValue(emailTemplate.Name)
-	plan.From = types.StringValue(emailTemplate.From)
+	plan.From = r.fromOverrides.stored(plan.From, emailTemplate.From)
+	plan.EffectiveFrom = types.StringValue(emailTemplate.From)
 	plan.ReplyTo = types.StringValue(emailTemplate.ReplyTo)
 	plan.Subject = types.StringValue(emailTemplate.Subject)
-	plan.Preheader = types.StringValue(emailTemplate.Preheader)
+	setPreheaderFromAPI(ctx, &plan, emailTemplate.Preheader)
 	// Format stored HTML as well
 	plan.Html = types.StringValue(normalizeHTML(emailTemplate.HTML))
+	plan.Placeholders = placeholdersValue(plan.Html.ValueString(), r.placeholderSyntax)
+	plan.HtmlSummary = htmlSummaryValue(plan.Html)
+	if !plan.ParentID.IsNull() {
+		plan.ResolvedHtml = types.StringValue(resolvedHTML)
+	}
 	plan.IsHtmlEditable = types.BoolValue(emailTemplate.IsHTMLEditable)
 	plan.LandingPage = types.StringValue(emailTemplate.LandingPageID)
 	plan.ImagePreviewUrl = types.StringValue(emailTemplate.ImagePreviewURL)
-	plan.CreatedAt = types.StringValue(time.Now().Format(time.RFC850))
-	plan.UpdatedAt = types.StringValue(time.Now().Format(time.RFC850))
+	clearUnmanagedFields(ctx, &plan, managed)
+	plan.CreatedAt = types.StringValue(r.timestamps.now())
+	plan.UpdatedAt = types.StringValue(r.timestamps.now())
+	plan.ProviderVersion = types.StringValue(r.version)
+
+	// The preview image is generated asynchronously after create.
+	if plan.WaitForPreview.ValueBool() && emailTemplate.ImagePreviewURL == "" {
+		previewURL := r.waitForPreview(ctx, auth, emailTemplate.ID)
+		if previewURL == "" {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("image_preview_url"),
+				"Preview Image Not Ready",
+				fmt.Sprintf("Infobip did not generate the preview image of email template ID %d within %s. "+
+					"image_preview_url will be populated by a later refresh.", emailTemplate.ID, previewWaitTimeout),
+			)
+		}
+		plan.ImagePreviewUrl = types.StringValue(previewURL)
+	}
+
+	// Remember whether landing_page came from configuration
+	var configLandingPage types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("landing_page"), &configLandingPage)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyLandingPageConfigured, landingPageConfiguredValue(configLandingPage))...)
+	if r.inlineRemoteImages {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyHTMLSource, htmlSourceValue(sourceHTML))...)
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -197,6 +867,12 @@ func (r *EmailTemplateResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *EmailTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
 	// Get current state
 	var state EmailTemplateResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -204,12 +880,13 @@ func (r *EmailTemplateResource) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withRequestHeaders(ctx, state.RequestHeaders)
 
-	auth := context.WithValue(
-		context.Background(),
-		infobip.ContextAPIKeys,
-		map[string]infobip.APIKey{"APIKeyHeader": {Key: r.apiKey, Prefix: "App"}},
-	)
+	resp.Diagnostics.Append(r.deadline.check("read email template ID " + state.ID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
 
 	var idInt int64
 	_, err := fmt.Sscanf(state.ID.ValueString(), "%d", &idInt)
@@ -231,20 +908,57 @@ func (r *EmailTemplateResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	// Some gateways answer 200 with an empty body instead of 404 for a
+	// missing template; treat that as not found rather than storing zeros.
+	if isEmptyEmailTemplate(emailTemplate) {
+		tflog.Warn(ctx, "Email template read returned an empty body; removing from state", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if r.managedIDs.claim(state.ID.ValueString()) {
+		resp.Diagnostics.AddWarning(
+			"Email Template Managed More Than Once",
+			fmt.Sprintf("Email template %s is managed by more than one resource in this configuration, so their applies overwrite each other. "+
+				"Remove all but one of the resources, for example with a removed block. "+
+				"This is only detected within one provider configuration; resources in other workspaces are not checked.", state.ID.ValueString()),
+		)
+	}
+
+	stale := staleComputedAttributes(state)
+	managed := state
+
 	// Overwrite items with refreshed state
 	state.ID = types.StringValue(fmt.Sprintf("%d", emailTemplate.ID))
 	state.Name = types.StringValue(emailTemplate.Name)
-	state.From = types.StringValue(emailTemplate.From)
+	state.From = r.fromOverrides.stored(state.From, emailTemplate.From)
+	state.EffectiveFrom = types.StringValue(emailTemplate.From)
 	state.ReplyTo = types.StringValue(emailTemplate.ReplyTo)
 	state.Subject = types.StringValue(emailTemplate.Subject)
-	state.Preheader = types.StringValue(emailTemplate.Preheader)
+	setPreheaderFromAPI(ctx, &state, emailTemplate.Preheader)
 	// Format HTML when mapping back to state
 	state.Html = types.StringValue(normalizeHTML(emailTemplate.HTML))
+	state.Placeholders = placeholdersValue(state.Html.ValueString(), r.placeholderSyntax)
+	state.HtmlSummary = htmlSummaryValue(state.Html)
 	state.IsHtmlEditable = types.BoolValue(emailTemplate.IsHTMLEditable)
 	state.LandingPage = types.StringValue(emailTemplate.LandingPageID)
 	state.ImagePreviewUrl = types.StringValue(emailTemplate.ImagePreviewURL)
 	state.CreatedAt = types.StringValue(emailTemplate.CreatedAt)
 	state.UpdatedAt = types.StringValue(emailTemplate.UpdatedAt)
+	repairHTMLRaw(&state, emailTemplate.HTML)
+	clearUnmanagedFields(ctx, &state, managed)
+	state.RawJson = rawJSONValue(state.ExposeRawJson, httpResponse)
+	state.Etag = responseETag(httpResponse)
+	// Imported templates, and those written before provider_version
+	// existed, have none yet.
+	if state.ProviderVersion.IsNull() {
+		state.ProviderVersion = types.StringValue(r.version)
+	}
+	if len(stale) > 0 {
+		tflog.Info(ctx, "Repaired stale computed attributes of email template", map[string]any{"id": state.ID.ValueString(), "attributes": stale})
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyAPIUpdatedAt, apiUpdatedAtValue(emailTemplate.UpdatedAt))...)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -255,6 +969,12 @@ func (r *EmailTemplateResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *EmailTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
 	// Read plan and prior state
 	var plan EmailTemplateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -268,13 +988,33 @@ func (r *EmailTemplateResource) Update(ctx context.Context, req resource.UpdateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withRequestHeaders(ctx, plan.RequestHeaders)
+
+	resp.Diagnostics.Append(checkHTMLChecksum(ctx, req.Config, plan.HtmlChecksum)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only attributes that are not sent changed, such as
+	// rollback_on_update_failure, or the plan churned without changing the
+	// template: store the plan without calling the API.
+	if isNoopUpdate(ctx, state, plan) {
+		tflog.Debug(ctx, "Skipping email template update without changes", map[string]any{"id": state.ID.ValueString()})
+		keepComputedFromState(&plan, state)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	changes := emailTemplateChanges(state, plan)
+	changes["id"] = state.ID.ValueString()
+	tflog.Debug(ctx, "Updating email template", changes)
 
 	// Prepare auth context
-	auth := context.WithValue(
-		context.Background(),
-		infobip.ContextAPIKeys,
-		map[string]infobip.APIKey{"APIKeyHeader": {Key: r.apiKey, Prefix: "App"}},
-	)
+	resp.Diagnostics.Append(r.deadline.check("update email template ID " + state.ID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
 
 	// Call update API
 	var idInt int64
@@ -282,48 +1022,229 @@ func (r *EmailTemplateResource) Update(ctx context.Context, req resource.UpdateR
 	if err != nil {
 		return
 	}
+	// Unchanged html is the stored html, which is already composed.
+	htmlChanged := !plan.Html.Equal(state.Html)
+	resolvedHTML := plan.Html.ValueString()
+	if htmlChanged {
+		resolvedHTML, err = r.resolveHTML(auth, plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("parent_id"), "Error Composing Email Template", err.Error())
+			return
+		}
+	}
+	sourceHTML, err := r.outgoingHTML(resolvedHTML, plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("html"),
+			"Error Preparing HTML",
+			"Could not prepare the email template html: "+err.Error(),
+		)
+		return
+	}
+
+	// The sent html also depends on asset_base_url, send_normalized_html
+	// and minify_html, so the stored html is only sent again when it would
+	// be sent as it was last sent.
+	lastSent := state.HtmlRaw.ValueString()
+	if state.HtmlRaw.IsNull() || state.HtmlRaw.IsUnknown() || lastSent == "" {
+		lastSent = state.Html.ValueString()
+	}
+	sendStored := !htmlChanged && normalizeHTML(sourceHTML) == normalizeHTML(lastSent)
+
+	sentHTML := sourceHTML
+	if r.inlineRemoteImages && !sendStored {
+		sentHTML = r.inlineImages(ctx, sourceHTML, &resp.Diagnostics)
+	}
+
+	// The API replaces every field and requires all of them, so fields the
+	// plan does not change are sent as last read. Infobip stores the same
+	// values unless the template was edited, such as in the Infobip UI,
+	// since the last refresh, and the check below then fails the update
+	// until a refresh reads the edits. The template is only read again when
+	// there is no ETag to make the update conditional on, when unmanaged
+	// fields are merged, or when the stored html is resent with images
+	// inlined, which html_raw does not hold.
+	merge := plan.MergeUnmanagedFields.ValueBool() && (plan.ReplyTo.IsNull() || effectivePreheader(ctx, plan).IsNull())
+	current := storedEmailTemplate(ctx, state)
+	if state.Etag.ValueString() == "" || merge || (sendStored && r.inlineRemoteImages) {
+		var httpResponse *http.Response
+		current, httpResponse, err = r.infobipClient.
+			EmailAPI.
+			GetEmailTemplate(auth).
+			ID(idInt).
+			Execute()
+
+		tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+		if err == nil && isEmptyEmailTemplate(current) {
+			err = errors.New("the template was not found")
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Email Template",
+				"Could not read the current email template ID "+state.ID.String()+" before updating it: "+err.Error(),
+			)
+			return
+		}
+	}
+	if sendStored {
+		sentHTML = current.HTML
+	}
+
+	// Fail instead of overwriting edits made since the last refresh: with
+	// an ETag, Infobip rejects the update itself; without one, compare the
+	// updated_at last seen.
+	conditional := auth
+	if etag := state.Etag.ValueString(); etag != "" {
+		conditional = withIfMatch(auth, etag)
+	} else {
+		known, diags := req.Private.GetKey(ctx, privateKeyAPIUpdatedAt)
+		resp.Diagnostics.Append(diags...)
+		if updatedSince(apiUpdatedAtFromPrivate(known), current.UpdatedAt) {
+			addConcurrentUpdateError(&resp.Diagnostics, state.ID)
+			return
+		}
+	}
+
+	// Reading the template may have taken the rest of the budget.
+	resp.Diagnostics.Append(r.deadline.check("update email template ID " + state.ID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	emailTemplate, httpResponse, err := r.infobipClient.
 		EmailAPI.
-		UpdateEmailTemplate(auth).
+		UpdateEmailTemplate(conditional).
 		ID(idInt).
-		Name(plan.Name.ValueString()).
-		From(plan.From.ValueString()).
-		ReplyTo(plan.ReplyTo.ValueString()).
-		Subject(plan.Subject.ValueString()).
-		Preheader(plan.Preheader.ValueString()).
-		Html(plan.Html.ValueString()).
-		LandingPage(plan.LandingPage.ValueString()).
+		Name(changedValue(plan.Name, state.Name, current.Name)).
+		From(changedValue(r.fromOverrides.apply(plan.From), state.EffectiveFrom, current.From)).
+		ReplyTo(mergedValue(plan.MergeUnmanagedFields.ValueBool(), plan.ReplyTo, state.ReplyTo, current.ReplyTo)).
+		Subject(changedValue(plan.Subject, state.Subject, current.Subject)).
+		Preheader(mergedValue(plan.MergeUnmanagedFields.ValueBool(), effectivePreheader(ctx, plan), effectivePreheader(ctx, state), current.Preheader)).
+		Html(sentHTML).
+		LandingPage(changedValue(plan.LandingPage, state.LandingPage, current.LandingPageID)).
 		Execute()
 
 	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	rawJSON := rawJSONValue(plan.ExposeRawJson, httpResponse)
+	etag := responseETag(httpResponse)
 
+	// Nothing was applied, so there is nothing to roll back.
+	if isPreconditionFailed(httpResponse) {
+		addConcurrentUpdateError(&resp.Diagnostics, state.ID)
+		return
+	}
 	if err != nil {
+		detail := "An error was encountered while updating the email template: " + err.Error()
+		if plan.RollbackOnUpdateFailure.ValueBool() {
+			if rollbackErr := r.rollback(ctx, auth, idInt, state); rollbackErr != nil {
+				detail += "\n\nRolling back to the prior configuration also failed: " + rollbackErr.Error()
+			} else {
+				detail += "\n\nThe email template was rolled back to its prior configuration."
+			}
+		}
+
+		resp.Diagnostics.AddError("Error Updating Email Template", detail)
+		return
+	}
+
+	if isEmptyEmailTemplate(emailTemplate) {
 		resp.Diagnostics.AddError(
-			"Error Updating Email Template",
-			"An error was encountered while updating the email template: "+err.Error(),
+			"Invalid Email Template ID",
+			fmt.Sprintf("Infobip reported success updating email template ID %s but returned template ID %d, which is not a valid ID.",
+				state.ID.String(), emailTemplateID(emailTemplate)),
 		)
 		return
 	}
 
+	// Confirm a cleared landing page was actually disassociated
+	if plan.LandingPage.ValueString() == "" && state.LandingPage.ValueString() != "" {
+		refreshed, httpResponse, err := r.infobipClient.
+			EmailAPI.
+			GetEmailTemplate(auth).
+			ID(idInt).
+			Execute()
+
+		tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Email Template",
+				"Could not confirm the landing page was cleared for email template ID "+state.ID.String()+": "+err.Error(),
+			)
+			return
+		}
+
+		if isEmptyEmailTemplate(refreshed) {
+			resp.Diagnostics.AddError(
+				"Error Reading Email Template",
+				"Could not confirm the landing page was cleared for email template ID "+state.ID.String()+": the template was not found.",
+			)
+			return
+		}
+
+		if refreshed.LandingPageID != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("landing_page"),
+				"Landing Page Not Cleared",
+				fmt.Sprintf("The landing page was removed from configuration, but Infobip still reports landing page %q for email template ID %s.", refreshed.LandingPageID, state.ID.String()),
+			)
+			return
+		}
+
+		emailTemplate = refreshed
+		rawJSON = rawJSONValue(plan.ExposeRawJson, httpResponse)
+		etag = responseETag(httpResponse)
+	}
+
 	// Map response back to state (preserve created_at if not returned)
+	if plan.HtmlRaw.IsUnknown() {
+		plan.HtmlRaw = types.StringValue(sentHTML)
+	}
+	managed := plan
+	plan.RawJson = rawJSON
+	plan.Etag = etag
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyAPIUpdatedAt, apiUpdatedAtValue(emailTemplate.UpdatedAt))...)
 	plan.ID = types.StringValue(fmt.Sprintf("%d", emailTemplate.ID))
 	plan.Name = types.StringValue(emailTemplate.Name)
-	plan.From = types.StringValue(emailTemplate.From)
+	plan.From = r.fromOverrides.stored(plan.From, emailTemplate.From)
+	plan.EffectiveFrom = types.StringValue(emailTemplate.From)
 	plan.ReplyTo = types.StringValue(emailTemplate.ReplyTo)
 	plan.Subject = types.StringValue(emailTemplate.Subject)
-	plan.Preheader = types.StringValue(emailTemplate.Preheader)
+	setPreheaderFromAPI(ctx, &plan, emailTemplate.Preheader)
 	plan.Html = types.StringValue(normalizeHTML(emailTemplate.HTML))
-	plan.IsHtmlEditable = types.BoolValue(emailTemplate.IsHTMLEditable)
+	plan.Placeholders = placeholdersValue(plan.Html.ValueString(), r.placeholderSyntax)
+	plan.HtmlSummary = htmlSummaryValue(plan.Html)
+	if !plan.ParentID.IsNull() && htmlChanged {
+		plan.ResolvedHtml = types.StringValue(resolvedHTML)
+	}
+	// Values kept from state by the plan must not change on apply; a later
+	// refresh picks up any change.
+	if plan.IsHtmlEditable.IsUnknown() {
+		plan.IsHtmlEditable = types.BoolValue(emailTemplate.IsHTMLEditable)
+	}
 	plan.LandingPage = types.StringValue(emailTemplate.LandingPageID)
-	plan.ImagePreviewUrl = types.StringValue(emailTemplate.ImagePreviewURL)
+	clearUnmanagedFields(ctx, &plan, managed)
+	if plan.ImagePreviewUrl.IsUnknown() {
+		plan.ImagePreviewUrl = types.StringValue(emailTemplate.ImagePreviewURL)
+	}
 
 	// Preserve created_at from prior state if API doesn't return it
-	if state.CreatedAt.ValueString() != "" {
+	if plan.CreatedAt.IsUnknown() {
 		plan.CreatedAt = state.CreatedAt
-	} else {
-		plan.CreatedAt = types.StringValue(time.Now().Format(time.RFC850))
+		if state.CreatedAt.ValueString() == "" {
+			plan.CreatedAt = types.StringValue(r.timestamps.now())
+		}
+	}
+	plan.UpdatedAt = types.StringValue(r.timestamps.now())
+	plan.ProviderVersion = types.StringValue(r.version)
+
+	// Remember whether landing_page came from configuration
+	var configLandingPage types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("landing_page"), &configLandingPage)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyLandingPageConfigured, landingPageConfiguredValue(configLandingPage))...)
+	// html sent as stored is the stored, already inlined html: keep the
+	// prior source.
+	if r.inlineRemoteImages && !sendStored {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyHTMLSource, htmlSourceValue(sourceHTML))...)
 	}
-	plan.UpdatedAt = types.StringValue(time.Now().Format(time.RFC850))
 
 	// Set updated state
 	diags = resp.State.Set(ctx, plan)
@@ -334,6 +1255,12 @@ func (r *EmailTemplateResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *EmailTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
 	var data EmailTemplateResourceModel
 
 	// Read Terraform prior state data into the model
@@ -341,13 +1268,22 @@ func (r *EmailTemplateResource) Delete(ctx context.Context, req resource.DeleteR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withRequestHeaders(ctx, data.RequestHeaders)
+
+	if err := r.deleteGuard.allow(); err != nil {
+		resp.Diagnostics.AddError(
+			"Bulk Delete Not Confirmed",
+			"Could not delete email template ID "+data.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
 
 	// Prepare auth context
-	auth := context.WithValue(
-		context.Background(),
-		infobip.ContextAPIKeys,
-		map[string]infobip.APIKey{"APIKeyHeader": {Key: r.apiKey, Prefix: "App"}},
-	)
+	resp.Diagnostics.Append(r.deadline.check("delete email template ID " + data.ID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
 
 	// Call delete API
 	var idInt int64
@@ -383,7 +1319,325 @@ func (r *EmailTemplateResource) Delete(ctx context.Context, req resource.DeleteR
 }
 
 func (r *EmailTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	id, expectedName, all, err := parseImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	// Terraform imports a single resource per import ID, so importing every
+	// template is left to import blocks; the templates are listed to tell
+	// how many there are.
+	if all {
+		resp.Diagnostics.Append(r.deadline.check("list email templates")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+		items, err := listEmailTemplates(auth, r.infobipClient)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing Email Templates",
+				"An error was encountered while listing email templates: "+err.Error(),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Cannot Import Every Email Template At Once",
+			fmt.Sprintf("Terraform imports a single resource per import ID, so %s cannot import the %d email templates of the account. "+
+				"Import them with an import block for every template instead, such as:\n\n%s\n\n"+
+				"and generate their configuration with terraform plan -generate-config-out=generated.tf.", importAllID, len(items), importAllExample),
+		)
+		return
+	}
+
+	// A pinned name guards against importing the wrong template, such as
+	// from a mistyped or copy-pasted ID.
+	if expectedName != "" {
+		resp.Diagnostics.Append(r.deadline.check(fmt.Sprintf("read email template ID %d", id))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+		emailTemplate, httpResponse, err := r.infobipClient.
+			EmailAPI.
+			GetEmailTemplate(auth).
+			ID(id).
+			Execute()
+
+		tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+		if err == nil && isEmptyEmailTemplate(emailTemplate) {
+			err = errors.New("the template was not found")
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Email Template",
+				fmt.Sprintf("Could not read email template ID %d to check its name: %s", id, err),
+			)
+			return
+		}
+
+		if emailTemplate.Name != expectedName {
+			resp.Diagnostics.AddError(
+				"Email Template Name Mismatch",
+				fmt.Sprintf("Email template ID %d is named %q, not %q as the import ID expects, so it was not imported. "+
+					"Check that the ID is the one of the intended template.", id, emailTemplate.Name, expectedName),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%d", id))...)
+}
+
+// isEmptyEmailTemplate reports whether a successful response carried no
+// valid template, which happens when the body is empty. IDs are positive, so
+// a zero or negative ID is treated the same way.
+func isEmptyEmailTemplate(emailTemplate *email.CreateEmailTemplateResponse) bool {
+	return emailTemplate == nil || emailTemplate.ID <= 0
+}
+
+// storedEmailTemplate returns the fields of the template as last read into
+// state, as the API would return them. Its html is the html last sent.
+func storedEmailTemplate(ctx context.Context, state EmailTemplateResourceModel) *email.CreateEmailTemplateResponse {
+	from := state.EffectiveFrom
+	if from.IsNull() || from.IsUnknown() {
+		from = state.From
+	}
+
+	html := state.HtmlRaw.ValueString()
+	if state.HtmlRaw.IsNull() || state.HtmlRaw.IsUnknown() || html == "" {
+		html = state.Html.ValueString()
+	}
+
+	return &email.CreateEmailTemplateResponse{
+		Name:          state.Name.ValueString(),
+		From:          from.ValueString(),
+		ReplyTo:       state.ReplyTo.ValueString(),
+		Subject:       state.Subject.ValueString(),
+		Preheader:     effectivePreheader(ctx, state).ValueString(),
+		HTML:          html,
+		LandingPageID: state.LandingPage.ValueString(),
+	}
+}
+
+// emailTemplateID returns the ID of emailTemplate, or 0 when it is nil.
+func emailTemplateID(emailTemplate *email.CreateEmailTemplateResponse) int64 {
+	if emailTemplate == nil {
+		return 0
+	}
+
+	return emailTemplate.ID
+}
+
+// isEditorSwitch reports whether the planned html would move a template out
+// of the drag-and-drop editor. Templates built in the drag-and-drop editor
+// are not HTML editable, so any html change beyond whitespace turns them
+// into code-editor templates.
+func isEditorSwitch(state, plan EmailTemplateResourceModel) bool {
+	if state.IsHtmlEditable.IsNull() || state.IsHtmlEditable.IsUnknown() || state.IsHtmlEditable.ValueBool() {
+		return false
+	}
+	if plan.Html.IsUnknown() {
+		return false
+	}
+
+	return normalizeHTML(plan.Html.ValueString()) != normalizeHTML(state.Html.ValueString())
+}
+
+// isNoopUpdate reports whether plan sends Infobip the same template as
+// state, so an update has nothing to write. Fields are compared as they are
+// sent, so an unset optional field equals an empty one. Unknown fields, such
+// as html_raw when the sent html changes, are never equal.
+func isNoopUpdate(ctx context.Context, state, plan EmailTemplateResourceModel) bool {
+	fields := [][2]types.String{
+		{state.Name, plan.Name},
+		{state.From, plan.From},
+		{state.EffectiveFrom, plan.EffectiveFrom},
+		{state.ReplyTo, plan.ReplyTo},
+		{state.Subject, plan.Subject},
+		{effectivePreheader(ctx, state), effectivePreheader(ctx, plan)},
+		{state.Html, plan.Html},
+		{state.HtmlRaw, plan.HtmlRaw},
+		{state.ResolvedHtml, plan.ResolvedHtml},
+		{state.LandingPage, plan.LandingPage},
+	}
+	for _, field := range fields {
+		if field[0].IsUnknown() || field[1].IsUnknown() || field[0].ValueString() != field[1].ValueString() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keepComputedFromState sets the computed attributes of plan that are
+// unknown to their values in state, for an update that did not call the API.
+func keepComputedFromState(plan *EmailTemplateResourceModel, state EmailTemplateResourceModel) {
+	values := []struct {
+		planned *types.String
+		prior   types.String
+	}{
+		{&plan.ID, state.ID},
+		{&plan.EffectivePreheader, state.EffectivePreheader},
+		{&plan.ResolvedHtml, state.ResolvedHtml},
+		{&plan.RawJson, state.RawJson},
+		{&plan.ImagePreviewUrl, state.ImagePreviewUrl},
+		{&plan.CreatedAt, state.CreatedAt},
+		{&plan.UpdatedAt, state.UpdatedAt},
+		{&plan.HtmlSummary, state.HtmlSummary},
+		{&plan.Etag, state.Etag},
+		{&plan.LandingPage, state.LandingPage},
+	}
+	for _, s := range values {
+		if s.planned.IsUnknown() {
+			*s.planned = s.prior
+		}
+	}
+
+	if plan.IsHtmlEditable.IsUnknown() {
+		plan.IsHtmlEditable = state.IsHtmlEditable
+	}
+	if plan.Placeholders.IsUnknown() {
+		plan.Placeholders = state.Placeholders
+	}
+}
+
+// rollback restores the template to the prior state after a failed update.
+// The html and from are restored as last sent when they are known.
+func (r *EmailTemplateResource) rollback(ctx context.Context, auth context.Context, id int64, state EmailTemplateResourceModel) error {
+	html := state.HtmlRaw.ValueString()
+	if state.HtmlRaw.IsNull() || state.HtmlRaw.IsUnknown() || html == "" {
+		html = state.Html.ValueString()
+	}
+	from := state.EffectiveFrom
+	if from.IsNull() || from.IsUnknown() {
+		from = state.From
+	}
+
+	tflog.Info(ctx, "Rolling back email template after a failed update", map[string]any{"id": id})
+
+	_, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		UpdateEmailTemplate(auth).
+		ID(id).
+		Name(state.Name.ValueString()).
+		From(from.ValueString()).
+		ReplyTo(state.ReplyTo.ValueString()).
+		Subject(state.Subject.ValueString()).
+		Preheader(effectivePreheader(ctx, state).ValueString()).
+		Html(html).
+		LandingPage(state.LandingPage.ValueString()).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+
+	return err
+}
+
+// changedValue returns the planned value of a field when the plan changes
+// it, and the currently stored value otherwise.
+func changedValue(planned, prior types.String, current string) string {
+	if planned.Equal(prior) {
+		return current
+	}
+
+	return planned.ValueString()
+}
+
+// isWhitespaceOnly reports whether value is known and consists solely of
+// whitespace. The empty string does not count.
+func isWhitespaceOnly(value types.String) bool {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return false
+	}
+
+	return strings.TrimSpace(value.ValueString()) == ""
+}
+
+// whitespaceEqual reports whether two known, non-null values only differ
+// by leading, trailing or repeated whitespace.
+func whitespaceEqual(a, b types.String) bool {
+	if a.IsUnknown() || a.IsNull() || b.IsUnknown() || b.IsNull() {
+		return false
+	}
+
+	return collapseWhitespace(a.ValueString()) == collapseWhitespace(b.ValueString())
+}
+
+// collapseWhitespace trims s and replaces every run of whitespace in it with
+// a single space.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// outgoingHTML returns the html to send to Infobip before remote images are
+// inlined: raw with relative URLs resolved against the asset_base_url of
+// plan when it is set, normalized when send_normalized_html is set, and
+// minified when minify_html is enabled.
+func (r *EmailTemplateResource) outgoingHTML(raw string, plan EmailTemplateResourceModel) (string, error) {
+	if assetBaseURL := plan.AssetBaseUrl.ValueString(); assetBaseURL != "" {
+		base, err := parseAssetBaseURL(assetBaseURL)
+		if err != nil {
+			return "", err
+		}
+		raw = rewriteAssetURLs(raw, base)
+	}
+
+	if plan.SendNormalizedHtml.ValueBool() {
+		raw = normalizeHTML(raw)
+	}
+
+	if !r.minifyHTML {
+		return raw, nil
+	}
+
+	return minifyHTML(raw)
+}
+
+// resolveHTML returns the html of plan composed into the html of its parent
+// template, or the html itself when parent_id is unset.
+func (r *EmailTemplateResource) resolveHTML(auth context.Context, plan EmailTemplateResourceModel) (string, error) {
+	if plan.ParentID.IsNull() {
+		return plan.Html.ValueString(), nil
+	}
+
+	var parentID int64
+	if _, err := fmt.Sscanf(plan.ParentID.ValueString(), "%d", &parentID); err != nil {
+		return "", fmt.Errorf("parent_id %s is not a valid email template ID", plan.ParentID.String())
+	}
+
+	parent, _, err := r.infobipClient.
+		EmailAPI.
+		GetEmailTemplate(auth).
+		ID(parentID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf("could not read parent email template ID %d: %w", parentID, err)
+	}
+	if isEmptyEmailTemplate(parent) {
+		return "", fmt.Errorf("parent email template ID %d was not found", parentID)
+	}
+
+	return composeHTML(parent.HTML, plan.Html.ValueString())
+}
+
+// inlineImages inlines the remote images of html, adding a warning for each
+// image that keeps its URL.
+func (r *EmailTemplateResource) inlineImages(ctx context.Context, html string, diags *diag.Diagnostics) string {
+	inlined, warnings := inlineRemoteImages(ctx, r.httpClient, html)
+	for _, warning := range warnings {
+		diags.AddAttributeWarning(path.Root("html"), "Remote Image Not Inlined", warning)
+	}
+
+	return inlined
 }
 
 func normalizeHTML(raw string) string {
@@ -0,0 +1,1009 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testEmailTemplateConfig(overrides map[string]tftypes.Value) map[string]tftypes.Value {
+	config := map[string]tftypes.Value{
+		"name":    tfString("Welcome"),
+		"from":    tfString("Sender <sender@example.com>"),
+		"subject": tfString("Welcome aboard"),
+		"html":    tfString("<html><body><h1>Hi</h1></body></html>"),
+	}
+	for k, v := range overrides {
+		config[k] = v
+	}
+
+	return config
+}
+
+func TestEmailTemplateResource_LandingPageSetClearRead(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	// Set
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"landing_page": tfString("1_2345")})); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("landing_page"); got != "1_2345" {
+		t.Fatalf("landing_page after create = %q, want %q", got, "1_2345")
+	}
+
+	// Clear
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("clear: %s", diagnosticsString(diags))
+	}
+	form := mock.lastForm()
+	if got, ok := form["landingPage"]; !ok || got != "" {
+		t.Fatalf("update landingPage form value = %q (sent %t), want explicit empty value", got, ok)
+	}
+	if got := r.stringAttr("landing_page"); got != "" {
+		t.Fatalf("landing_page after clear = %q, want empty", got)
+	}
+
+	// Read
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("landing_page"); got != "" {
+		t.Fatalf("landing_page after refresh = %q, want empty", got)
+	}
+}
+
+func TestEmailTemplateResource_LandingPageNeverSetKeepsServerValue(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	// The server assigns a landing page the practitioner never configured.
+	mock.mu.Lock()
+	for _, tmpl := range mock.templates {
+		tmpl.LandingPageID = "9_9999"
+	}
+	mock.mu.Unlock()
+
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"subject": tfString("Changed")})); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if got := mock.lastForm()["landingPage"]; got != "9_9999" {
+		t.Fatalf("update landingPage form value = %q, want server value kept", got)
+	}
+	if got := r.stringAttr("landing_page"); got != "9_9999" {
+		t.Fatalf("landing_page after update = %q, want %q", got, "9_9999")
+	}
+}
+
+func TestEmailTemplateResource_RecreateOnEditorSwitch(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+	config := testEmailTemplateConfig(map[string]tftypes.Value{"recreate_on_editor_switch": tfBool(true)})
+
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	originalID := r.stringAttr("id")
+
+	// An ordinary edit of a code-editor template updates in place.
+	config["html"] = tfString("<html><body><h1>Hello</h1></body></html>")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if r.replaced != 0 || r.stringAttr("id") != originalID {
+		t.Fatalf("ordinary edit replaced the template (replaced=%d, id %s -> %s)", r.replaced, originalID, r.stringAttr("id"))
+	}
+
+	// The template is switched to the drag-and-drop editor outside Terraform.
+	mock.mu.Lock()
+	mock.templates[1].IsHTMLEditable = false
+	mock.mu.Unlock()
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+
+	// Whitespace-only changes are not a switch.
+	config["html"] = tfString("<html>\n  <body><h1>Hello</h1></body>\n</html>")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("whitespace update: %s", diagnosticsString(diags))
+	}
+	if r.replaced != 0 {
+		t.Fatalf("whitespace-only change replaced the template")
+	}
+
+	config["html"] = tfString("<html><body><h1>Rewritten in code</h1></body></html>")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("switch: %s", diagnosticsString(diags))
+	}
+	if r.replaced != 1 || r.stringAttr("id") == originalID {
+		t.Fatalf("editor switch did not replace the template (replaced=%d, id %s)", r.replaced, r.stringAttr("id"))
+	}
+}
+
+func TestEmailTemplateResource_ReadEmptyBodyRemovesResource(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	mock.mu.Lock()
+	mock.emptyGet[1] = true
+	mock.mu.Unlock()
+
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	if !r.state.IsNull() {
+		t.Fatalf("expected resource to be removed from state, got %s", r.state)
+	}
+}
+
+func TestEmailTemplateResource_HtmlRawVerbatim(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	raw := "<html>\r\n  <body>\n\t<h1>Hi  there</h1>\n  </body>\n</html>\n"
+	config := testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString(raw)})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("html_raw"); got != raw {
+		t.Fatalf("html_raw = %q, want %q", got, raw)
+	}
+	if got := r.stringAttr("html"); got == raw {
+		t.Fatalf("html was not normalized: %q", got)
+	}
+
+	// A whitespace-only change plans no change to html_raw.
+	config["html"] = tfString("<html><body>\n<h1>Hi there</h1></body></html>")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("whitespace update: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	if !planned["html_raw"].Equal(tfString(raw)) {
+		t.Fatalf("planned html_raw = %s, want prior value kept", planned["html_raw"])
+	}
+
+	// A real change is recorded verbatim.
+	changed := "<html><body>\n<h1>Changed</h1></body></html>"
+	config["html"] = tfString(changed)
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("html_raw"); got != changed {
+		t.Fatalf("html_raw = %q, want %q", got, changed)
+	}
+}
+
+func TestEmailTemplateResource_CreateIfMissing(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{"create_if_missing": tfBool(true)})
+
+	t.Run("missing creates", func(t *testing.T) {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, nil)
+		r := h.resource("pocinfobipemails_email_template")
+
+		if diags := r.apply(config); hasError(diags) {
+			t.Fatalf("create: %s", diagnosticsString(diags))
+		}
+		if got := countRequests(mock, "POST /email/1/templates"); got != 1 {
+			t.Fatalf("POST requests = %d, want 1", got)
+		}
+	})
+
+	t.Run("present adopts", func(t *testing.T) {
+		mock := newMockInfobip(t)
+		existing := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Welcome", Subject: "Old subject"})
+		h := newTestHarness(t, mock, nil)
+		r := h.resource("pocinfobipemails_email_template")
+
+		if diags := r.apply(config); hasError(diags) {
+			t.Fatalf("create: %s", diagnosticsString(diags))
+		}
+		if got := countRequests(mock, "POST /email/1/templates"); got != 0 {
+			t.Fatalf("POST requests = %d, want adoption without create", got)
+		}
+		if got := r.stringAttr("id"); got != fmt.Sprintf("%d", existing) {
+			t.Fatalf("id = %s, want adopted %d", got, existing)
+		}
+		if got := r.stringAttr("subject"); got != "Welcome aboard" {
+			t.Fatalf("subject = %q, want configuration applied to the adopted template", got)
+		}
+	})
+
+	t.Run("concurrent creates of one name", func(t *testing.T) {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, nil)
+		mock.delay = 10 * time.Millisecond
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if diags := h.resource("pocinfobipemails_email_template").apply(config); hasError(diags) {
+					t.Errorf("create: %s", diagnosticsString(diags))
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := countRequests(mock, "POST /email/1/templates"); got != 1 {
+			t.Fatalf("POST requests = %d, want exactly 1", got)
+		}
+	})
+}
+
+func countRequests(mock *mockInfobip, request string) int {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+
+	count := 0
+	for _, r := range mock.requests {
+		if r == request {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestEmailTemplateResource_RollbackOnUpdateFailure(t *testing.T) {
+	const original = "<html>\n<body><h1>Hi</h1></body>\n</html>"
+	const broken = "<html><body><h1>Broken</h1></body></html>"
+
+	cases := map[string]struct {
+		rollback bool
+		failPuts int
+		wantPuts int
+		wantHTML string
+		wantText string
+	}{
+		"disabled": {
+			failPuts: 1,
+			wantPuts: 1,
+			wantHTML: broken,
+		},
+		"rolled back": {
+			rollback: true,
+			failPuts: 1,
+			wantPuts: 2,
+			wantHTML: original,
+			wantText: "rolled back to its prior configuration",
+		},
+		"rollback fails": {
+			rollback: true,
+			failPuts: 2,
+			wantPuts: 2,
+			wantHTML: original,
+			wantText: "Rolling back to the prior configuration also failed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(map[string]tftypes.Value{
+				"html":                       tfString(original),
+				"rollback_on_update_failure": tfBool(tc.rollback),
+			})
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+			// Applying the configuration again does not fail, and does not
+			// update the template.
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+
+			mock.mu.Lock()
+			mock.failPut[1] = tc.failPuts
+			mock.mu.Unlock()
+
+			config["html"] = tfString(broken)
+			diags := r.apply(config)
+			if !hasError(diags) {
+				t.Fatalf("expected the update to fail")
+			}
+			if !strings.Contains(diagnosticsString(diags), tc.wantText) {
+				t.Errorf("diagnostics = %s, want them to contain %q", diagnosticsString(diags), tc.wantText)
+			}
+
+			if got := countRequests(mock, "PUT /email/1/templates/1"); got != tc.wantPuts {
+				t.Errorf("PUT requests = %d, want %d", got, tc.wantPuts)
+			}
+			// The mock applies failed updates, so only a rollback restores
+			// the original html.
+			if got := mock.lastForm()["html"]; got != tc.wantHTML {
+				t.Errorf("last sent html = %q, want %q", got, tc.wantHTML)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_IgnorePreheaderWhitespace(t *testing.T) {
+	cases := map[string]struct {
+		ignore bool
+		want   string
+	}{
+		"disabled": {want: "  Hello   there \n"},
+		"enabled":  {ignore: true, want: "Hello there"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, map[string]tftypes.Value{"ignore_preheader_whitespace": tfBool(tc.ignore)})
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(map[string]tftypes.Value{
+				"preheader": tfString("Hello there"),
+				"reply_to":  tfString("support@example.com"),
+			})
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+
+			// Whitespace noise in both fields; only preheader is opted in.
+			config["preheader"] = tfString("  Hello   there \n")
+			config["reply_to"] = tfString(" support@example.com")
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+
+			var planned map[string]tftypes.Value
+			_ = r.planned.As(&planned)
+			if !planned["preheader"].Equal(tfString(tc.want)) {
+				t.Errorf("planned preheader = %s, want %q", planned["preheader"], tc.want)
+			}
+			if !planned["reply_to"].Equal(tfString(" support@example.com")) {
+				t.Errorf("planned reply_to = %s, want the configured value", planned["reply_to"])
+			}
+
+			// A change beyond whitespace is always planned as configured.
+			config["preheader"] = tfString("Hello again")
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+			if got := mock.lastForm()["preheader"]; got != "Hello again" {
+				t.Errorf("sent preheader = %q, want %q", got, "Hello again")
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_CreateInvalidID(t *testing.T) {
+	for _, id := range []int64{0, -5} {
+		t.Run(fmt.Sprintf("id %d", id), func(t *testing.T) {
+			mock := newMockInfobip(t)
+			mock.createID = &id
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			diags := r.apply(testEmailTemplateConfig(nil))
+			if !hasError(diags) {
+				t.Fatalf("expected an error for template ID %d", id)
+			}
+			if !strings.Contains(diagnosticsString(diags), "Invalid Email Template ID") {
+				t.Errorf("diagnostics = %s, want an invalid ID error", diagnosticsString(diags))
+			}
+			if !r.state.IsNull() {
+				t.Errorf("state = %s, want nothing persisted", r.state)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_EmptySubject(t *testing.T) {
+	h := newTestHarness(t, newMockInfobip(t), nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.validate(testEmailTemplateConfig(map[string]tftypes.Value{"subject": tfString("")}))
+	if !strings.Contains(diagnosticsString(diags), "Empty Email Template Subject") {
+		t.Errorf("diagnostics = %s, want an empty subject error", diagnosticsString(diags))
+	}
+
+	if diags := r.validate(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Errorf("validate: %s", diagnosticsString(diags))
+	}
+}
+
+func TestEmailTemplateResource_HTMLControlCharacters(t *testing.T) {
+	h := newTestHarness(t, newMockInfobip(t), nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.validate(testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString("<html><body>Hi\x00</body></html>")}))
+	if !strings.Contains(diagnosticsString(diags), "Control Character in HTML") || !strings.Contains(diagnosticsString(diags), "U+0000 at byte offset 14") {
+		t.Errorf("diagnostics = %s, want a control character error at offset 14", diagnosticsString(diags))
+	}
+
+	if diags := r.validate(testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString("<html>\r\n\t<body>Hi</body>\n</html>")})); hasError(diags) {
+		t.Errorf("validate: %s", diagnosticsString(diags))
+	}
+}
+
+func TestEmailTemplateResource_WhitespaceOnlyAsNull(t *testing.T) {
+	cases := map[string]struct {
+		enabled       bool
+		wantPreheader string
+		wantErr       bool
+	}{
+		"disabled": {wantPreheader: "   "},
+		"enabled":  {enabled: true, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, map[string]tftypes.Value{"whitespace_only_as_null": tfBool(tc.enabled)})
+			r := h.resource("pocinfobipemails_email_template")
+
+			// Subject
+			diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"subject": tfString(" \t ")}))
+			if hasError(diags) != tc.wantErr {
+				t.Fatalf("whitespace-only subject: diagnostics = %s, want error %t", diagnosticsString(diags), tc.wantErr)
+			}
+			if tc.wantErr && !strings.Contains(diagnosticsString(diags), "Empty Email Template Subject") {
+				t.Errorf("diagnostics = %s, want an empty subject error", diagnosticsString(diags))
+			}
+
+			// Preheader
+			r = h.resource("pocinfobipemails_email_template")
+			if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"preheader": tfString("   ")})); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+			if got := r.stringAttr("preheader"); got != tc.wantPreheader {
+				t.Errorf("preheader = %q, want %q", got, tc.wantPreheader)
+			}
+			if got := mock.lastForm()["preheader"]; got != tc.wantPreheader {
+				t.Errorf("sent preheader = %q, want %q", got, tc.wantPreheader)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_UpdateSendsUnchangedFields(t *testing.T) {
+	// With an ETag, Infobip rejects the update if the template changed since
+	// the last refresh, so it is not read first; without one, it is read to
+	// compare updated_at.
+	cases := map[string]struct {
+		etags    bool
+		wantGets int
+	}{
+		"etag":       {etags: true},
+		"updated_at": {wantGets: 1},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			mock.etags = tc.etags
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(map[string]tftypes.Value{"preheader": tfString("Hello")})
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+			path := "GET /email/1/templates/" + r.stringAttr("id")
+			gets := countRequests(mock, path)
+
+			config["subject"] = tfString("New subject")
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+
+			if got := countRequests(mock, path) - gets; got != tc.wantGets {
+				t.Errorf("reads before the update = %d, want %d", got, tc.wantGets)
+			}
+			// The API replaces every field, so unchanged ones are sent too.
+			form := mock.lastForm()
+			if form["subject"] != "New subject" {
+				t.Errorf("sent subject = %q, want the planned value", form["subject"])
+			}
+			if form["preheader"] != "Hello" || form["name"] == "" || form["from"] == "" || form["html"] == "" {
+				t.Errorf("sent form = %v, want the unchanged fields as stored", form)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_ComputedValuesKeptOnUpdate(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	prior := map[string]tftypes.Value{}
+	for _, name := range []string{"id", "image_preview_url", "is_html_editable", "created_at"} {
+		prior[name] = r.attr(name)
+	}
+
+	// A subject-only update keeps the computed values in the plan.
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"subject": tfString("Welcome back")})); hasError(diags) {
+		t.Fatalf("update subject: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	for name, value := range prior {
+		if !planned[name].Equal(value) {
+			t.Errorf("subject update planned %s = %s, want the prior %s", name, planned[name], value)
+		}
+	}
+	if planned["updated_at"].IsKnown() {
+		t.Errorf("subject update planned updated_at = %s, want unknown", planned["updated_at"])
+	}
+
+	// An html update refreshes the values that follow the html.
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{
+		"subject": tfString("Welcome back"),
+		"html":    tfString("<html><body><h1>Hello</h1></body></html>"),
+	})); hasError(diags) {
+		t.Fatalf("update html: %s", diagnosticsString(diags))
+	}
+	_ = r.planned.As(&planned)
+	for _, name := range []string{"image_preview_url", "is_html_editable"} {
+		if planned[name].IsKnown() {
+			t.Errorf("html update planned %s = %s, want unknown", name, planned[name])
+		}
+	}
+	if r.stringAttr("image_preview_url") == "" {
+		t.Error("image_preview_url is empty after the html update")
+	}
+}
+
+func TestEmailTemplateResource_ReadRepairsStaleComputedAttributes(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	want := map[string]tftypes.Value{
+		"image_preview_url": r.attr("image_preview_url"),
+		"html_raw":          r.attr("html_raw"),
+	}
+
+	// State stored by an older provider version lacks the computed
+	// attributes added since.
+	var attrs map[string]tftypes.Value
+	_ = r.state.As(&attrs)
+	for name := range want {
+		attrs[name] = tftypes.NewValue(tftypes.String, nil)
+	}
+	r.state = tftypes.NewValue(r.schema.ValueType(), attrs)
+
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	for name, value := range want {
+		if !r.attr(name).Equal(value) {
+			t.Errorf("refreshed %s = %s, want %s", name, r.attr(name), value)
+		}
+	}
+
+	// The repaired attributes plan no change.
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("plan: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	for name, value := range want {
+		if !planned[name].Equal(value) {
+			t.Errorf("planned %s = %s, want the refreshed %s", name, planned[name], value)
+		}
+	}
+}
+
+func TestEmailTemplateResource_NormalizeUnicode(t *testing.T) {
+	const (
+		composed   = "Caf\u00e9 news"
+		decomposed = "Cafe\u0301 news"
+	)
+	cases := map[string]struct {
+		normalize bool
+		want      string
+	}{
+		"disabled": {want: decomposed},
+		"enabled":  {normalize: true, want: composed},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, map[string]tftypes.Value{"normalize_unicode": tfBool(tc.normalize)})
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(map[string]tftypes.Value{
+				"subject":   tfString(composed),
+				"preheader": tfString(composed),
+				"from":      tfString("Caf\u00e9 <sender@example.com>"),
+			})
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+
+			// The same text, pasted from an editor that decomposes accents.
+			config["subject"] = tfString(decomposed)
+			config["preheader"] = tfString(decomposed)
+			config["from"] = tfString("Cafe\u0301 <sender@example.com>")
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+
+			var planned map[string]tftypes.Value
+			_ = r.planned.As(&planned)
+			for _, name := range []string{"subject", "preheader"} {
+				if !planned[name].Equal(tfString(tc.want)) {
+					t.Errorf("planned %s = %s, want %q", name, planned[name], tc.want)
+				}
+			}
+
+			// A change beyond composition is always planned as configured.
+			config["subject"] = tfString("Cafe news")
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+			if got := mock.lastForm()["subject"]; got != "Cafe news" {
+				t.Errorf("sent subject = %q, want %q", got, "Cafe news")
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_ManagedTwiceWarning(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+
+	first := h.resource("pocinfobipemails_email_template")
+	if diags := first.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	other := h.resource("pocinfobipemails_email_template")
+	if diags := other.apply(testEmailTemplateConfig(map[string]tftypes.Value{"name": tfString("Other")})); hasError(diags) {
+		t.Fatalf("create other: %s", diagnosticsString(diags))
+	}
+	// A second resource block imported the same template.
+	second := h.resource("pocinfobipemails_email_template")
+	second.state = first.state
+
+	for name, r := range map[string]*testResource{"first": first, "other": other} {
+		if diags := r.refresh(); strings.Contains(diagnosticsString(diags), "Managed More Than Once") {
+			t.Errorf("%s refresh: diagnostics = %s, want no duplicate warning", name, diagnosticsString(diags))
+		}
+	}
+	diags := second.refresh()
+	if hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	if got := diagnosticsString(diags); !strings.Contains(got, "Email template "+first.stringAttr("id")+" is managed by more than one resource") {
+		t.Errorf("diagnostics = %s, want the duplicate warning", got)
+	}
+}
+
+func TestEmailTemplateResource_SendNormalizedHTML(t *testing.T) {
+	const html = "<html>\r\n  <body>\n    <h1>Hi   there</h1>\n  </body>\n</html>\n"
+	cases := map[string]struct {
+		config tftypes.Value
+		want   string
+	}{
+		"unset":    {config: tftypes.NewValue(tftypes.Bool, nil), want: html},
+		"disabled": {config: tfBool(false), want: html},
+		"enabled":  {config: tfBool(true), want: "<html><body><h1>Hi there</h1></body></html>"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(map[string]tftypes.Value{
+				"html":                 tfString(html),
+				"send_normalized_html": tc.config,
+			})
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+			if got := mock.lastForm()["html"]; got != tc.want {
+				t.Errorf("sent html = %q, want %q", got, tc.want)
+			}
+			if got := r.stringAttr("html_raw"); got != tc.want {
+				t.Errorf("html_raw = %q, want %q", got, tc.want)
+			}
+			// html is stored normalized for diffing either way.
+			if got := r.stringAttr("html"); got != normalizeHTML(html) {
+				t.Errorf("stored html = %q, want %q", got, normalizeHTML(html))
+			}
+
+			// Updates are sent the same way.
+			config["subject"] = tfString("Welcome back")
+			config["html"] = tfString(strings.Replace(html, "Hi", "Hello", 1))
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+			if got, want := mock.lastForm()["html"], strings.Replace(tc.want, "Hi", "Hello", 1); got != want {
+				t.Errorf("sent html = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_RawJSON(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"preheader":       tfString("Hi"),
+		"reply_to":        tfString("reply@example.com"),
+		"expose_raw_json": tfBool(true),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	assertRawJSON := func(step string) {
+		t.Helper()
+
+		var body map[string]any
+		if err := json.Unmarshal([]byte(r.stringAttr("raw_json")), &body); err != nil {
+			t.Fatalf("%s: raw_json is not JSON: %s: %q", step, err, r.stringAttr("raw_json"))
+		}
+		if body["name"] != "Welcome" || body["subject"] != r.stringAttr("subject") || fmt.Sprint(body["id"]) != r.stringAttr("id") {
+			t.Errorf("%s: raw_json = %v, want the template fields", step, body)
+		}
+	}
+	assertRawJSON("create")
+
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	assertRawJSON("refresh")
+
+	// An unchanged configuration keeps the stored value.
+	stored := r.attr("raw_json")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("apply: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	if !planned["raw_json"].Equal(stored) {
+		t.Errorf("planned raw_json = %s, want the stored value", planned["raw_json"])
+	}
+
+	config["subject"] = tfString("Welcome back")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	assertRawJSON("update")
+
+	// Without the flag, raw_json is cleared.
+	delete(config, "expose_raw_json")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if !r.attr("raw_json").IsNull() {
+		t.Errorf("raw_json = %s, want null", r.attr("raw_json"))
+	}
+}
+
+func TestEmailTemplateResource_MergeUnmanagedFields(t *testing.T) {
+	cases := map[string]struct {
+		merge         bool
+		wantPreheader string
+		wantReplyTo   string
+	}{
+		"disabled": {},
+		"enabled":  {merge: true, wantPreheader: "Set in the UI", wantReplyTo: "ui@example.com"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(map[string]tftypes.Value{"merge_unmanaged_fields": tfBool(tc.merge)})
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+
+			// The preheader and reply-to are set in the UI.
+			id := mustParseID(t, r.stringAttr("id"))
+			mock.mu.Lock()
+			mock.templates[id].Preheader = "Set in the UI"
+			mock.templates[id].ReplyTo = "ui@example.com"
+			mock.mu.Unlock()
+			if diags := r.refresh(); hasError(diags) {
+				t.Fatalf("refresh: %s", diagnosticsString(diags))
+			}
+			if tc.merge && (!r.attr("preheader").IsNull() || !r.attr("reply_to").IsNull()) {
+				t.Errorf("preheader = %s, reply_to = %s, want unmanaged fields unset", r.attr("preheader"), r.attr("reply_to"))
+			}
+
+			config["subject"] = tfString("Welcome back")
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+			form := mock.lastForm()
+			if form["preheader"] != tc.wantPreheader || form["replyTo"] != tc.wantReplyTo {
+				t.Errorf("sent preheader = %q, replyTo = %q, want %q and %q", form["preheader"], form["replyTo"], tc.wantPreheader, tc.wantReplyTo)
+			}
+			if form["subject"] != "Welcome back" {
+				t.Errorf("sent subject = %q, want the configured subject", form["subject"])
+			}
+			if tc.merge && r.stringAttr("effective_preheader") != "Set in the UI" {
+				t.Errorf("effective_preheader = %q, want the preheader set in the UI", r.stringAttr("effective_preheader"))
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_ProviderVersion(t *testing.T) {
+	mock := newMockInfobip(t)
+	h, diags := configureVersionedTestHarness(t, mock, "1.0.0", nil)
+	if hasError(diags) {
+		t.Fatalf("ConfigureProvider: %s", diagnosticsString(diags))
+	}
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"preheader": tfString("Hi"),
+		"reply_to":  tfString("reply@example.com"),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("provider_version"); got != "1.0.0" {
+		t.Errorf("provider_version = %q, want 1.0.0", got)
+	}
+
+	// Upgrading the provider alone does not cause a diff.
+	upgraded, diags := configureVersionedTestHarness(t, mock, "1.1.0", nil)
+	if hasError(diags) {
+		t.Fatalf("ConfigureProvider: %s", diagnosticsString(diags))
+	}
+	unchanged := upgraded.resource("pocinfobipemails_email_template")
+	unchanged.state, unchanged.private = r.state, r.private
+	if diags := unchanged.apply(config); hasError(diags) {
+		t.Fatalf("apply: %s", diagnosticsString(diags))
+	}
+	if !unchanged.planned.Equal(r.state) {
+		t.Errorf("planned = %s, want the stored state %s", unchanged.planned, r.state)
+	}
+
+	// Changing the template records the version that updated it.
+	updated := upgraded.resource("pocinfobipemails_email_template")
+	updated.state, updated.private = r.state, r.private
+	config["subject"] = tfString("Welcome back")
+	if diags := updated.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if got := updated.stringAttr("provider_version"); got != "1.1.0" {
+		t.Errorf("provider_version = %q, want 1.1.0", got)
+	}
+}
+
+func TestEmailTemplateResource_SubjectNormalize(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"subject":           tfString("  Welcome  to   ACME\tnews "),
+		"preheader":         tfString("Hi"),
+		"reply_to":          tfString("reply@example.com"),
+		"subject_normalize": tfBool(true),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if got := mock.lastForm()["subject"]; got != "Welcome to ACME news" {
+		t.Errorf("sent subject = %q, want Welcome to ACME news", got)
+	}
+	if got := r.stringAttr("subject"); got != "Welcome to ACME news" {
+		t.Errorf("subject = %q, want Welcome to ACME news", got)
+	}
+
+	// Cosmetic whitespace changes do not change the subject.
+	config["subject"] = tfString("Welcome to  ACME news  ")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("apply: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	if !planned["subject"].Equal(tfString("Welcome to ACME news")) {
+		t.Errorf("planned subject = %s, want the stored subject", planned["subject"])
+	}
+
+	config["subject"] = tfString("   ")
+	if diags := r.apply(config); !strings.Contains(diagnosticsString(diags), "Empty Email Template Subject") {
+		t.Errorf("diagnostics = %s, want Empty Email Template Subject", diagnosticsString(diags))
+	}
+
+	// Without the flag, the subject is sent as configured.
+	config["subject"] = tfString("Welcome  to ACME")
+	delete(config, "subject_normalize")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if got := mock.lastForm()["subject"]; got != "Welcome  to ACME" {
+		t.Errorf("sent subject = %q, want Welcome  to ACME", got)
+	}
+}
+
+func TestEmailTemplateResource_NoopApplySkipsUpdate(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.etags = true
+	h := newTestHarness(t, mock, map[string]tftypes.Value{"minify_html": tfBool(true)})
+	r := h.resource("pocinfobipemails_email_template")
+
+	// reply_to and preheader are unset, so they plan as null against the
+	// empty values stored by Infobip.
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"html": tfString("<html>\n  <body>\n    <p>Hi {{first_name}}</p>\n  </body>\n</html>\n"),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	updatedAt, etag := r.stringAttr("updated_at"), r.stringAttr("etag")
+
+	applies := map[string]map[string]tftypes.Value{
+		"unchanged":            config,
+		"whitespace-only html": testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString("<html><body><p>Hi {{first_name}}</p></body></html>")}),
+		"unsent attribute":     testEmailTemplateConfig(map[string]tftypes.Value{"rollback_on_update_failure": tfBool(true), "html": config["html"]}),
+	}
+	for _, name := range []string{"unchanged", "whitespace-only html", "unsent attribute"} {
+		if diags := r.apply(applies[name]); hasError(diags) {
+			t.Fatalf("%s apply: %s", name, diagnosticsString(diags))
+		}
+		if got := countRequests(mock, "PUT /email/1/templates/1"); got != 0 {
+			t.Fatalf("%s apply: PUT requests = %d, want 0", name, got)
+		}
+		if got := r.stringAttr("updated_at"); got != updatedAt {
+			t.Errorf("%s apply: updated_at = %q, want %q", name, got, updatedAt)
+		}
+		if got := r.stringAttr("etag"); got != etag {
+			t.Errorf("%s apply: etag = %q, want %q", name, got, etag)
+		}
+	}
+
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"subject": tfString("Changed"), "html": config["html"]})); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if got := countRequests(mock, "PUT /email/1/templates/1"); got != 1 {
+		t.Errorf("PUT requests after a change = %d, want 1", got)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// emailTemplateSchemaVersion is the version of the email template resource
+// schema. Bump it, and add an upgrader to UpgradeState, whenever stored state
+// needs migrating, such as when an attribute is renamed or its format
+// changes.
+const emailTemplateSchemaVersion = 2
+
+// legacyTimestampLayouts are the layouts of timestamps found in state written
+// before created_at and updated_at were stored as RFC 3339. Until then they
+// were written as RFC 850 in local time.
+var legacyTimestampLayouts = []string{
+	time.RFC850,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC822,
+	time.RFC822Z,
+	"2006-01-02T15:04:05.000-0700",
+}
+
+var _ resource.ResourceWithUpgradeState = &EmailTemplateResource{}
+
+func (r *EmailTemplateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	v0 := emailTemplateSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &v0,
+			StateUpgrader: upgradeEmailTemplateStateV0,
+		},
+		// Version 1 state gained attributes over time, so no single schema
+		// decodes it; it is upgraded as raw JSON instead. Attributes missing
+		// from it are left null, but one that is removed from the schema
+		// must be deleted from it here.
+		1: {
+			StateUpgrader: upgradeEmailTemplateStateV1,
+		},
+	}
+}
+
+// emailTemplateSchemaV0 is the email template resource schema at version 0,
+// frozen so that version 0 state decodes whatever the current schema
+// becomes. Only what decoding needs is kept.
+func emailTemplateSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Computed: true},
+			"name":              schema.StringAttribute{Required: true},
+			"from":              schema.StringAttribute{Required: true},
+			"reply_to":          schema.StringAttribute{Optional: true},
+			"subject":           schema.StringAttribute{Required: true},
+			"preheader":         schema.StringAttribute{Optional: true},
+			"html":              schema.StringAttribute{Required: true},
+			"is_html_editable":  schema.BoolAttribute{Computed: true},
+			"landing_page":      schema.StringAttribute{Optional: true, Computed: true},
+			"image_preview_url": schema.StringAttribute{Computed: true},
+			"created_at":        schema.StringAttribute{Computed: true},
+			"updated_at":        schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+// emailTemplateResourceModelV0 is the state of emailTemplateSchemaV0.
+type emailTemplateResourceModelV0 struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	From            types.String `tfsdk:"from"`
+	ReplyTo         types.String `tfsdk:"reply_to"`
+	Subject         types.String `tfsdk:"subject"`
+	Preheader       types.String `tfsdk:"preheader"`
+	Html            types.String `tfsdk:"html"`
+	IsHtmlEditable  types.Bool   `tfsdk:"is_html_editable"`
+	LandingPage     types.String `tfsdk:"landing_page"`
+	ImagePreviewUrl types.String `tfsdk:"image_preview_url"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	UpdatedAt       types.String `tfsdk:"updated_at"`
+}
+
+// upgradeEmailTemplateStateV0 migrates version 0 state to the current
+// version, which stores created_at and updated_at as RFC 3339. Attributes
+// added since version 0 are left null.
+func upgradeEmailTemplateStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior emailTemplateResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.State.Raw = tftypes.NewValue(resp.State.Schema.Type().TerraformType(ctx), nil)
+	for name, value := range map[string]attr.Value{
+		"id":                prior.ID,
+		"name":              prior.Name,
+		"from":              prior.From,
+		"reply_to":          prior.ReplyTo,
+		"subject":           prior.Subject,
+		"preheader":         prior.Preheader,
+		"html":              prior.Html,
+		"is_html_editable":  prior.IsHtmlEditable,
+		"landing_page":      prior.LandingPage,
+		"image_preview_url": prior.ImagePreviewUrl,
+		"created_at":        upgradeTimestamp(prior.CreatedAt),
+		"updated_at":        upgradeTimestamp(prior.UpdatedAt),
+	} {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), value)...)
+	}
+}
+
+// upgradeEmailTemplateStateV1 migrates version 1 state to the current
+// version. Version 1 was introduced before created_at and updated_at were
+// written as RFC 3339, so its timestamps may still be legacy ones.
+func upgradeEmailTemplateStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The version 1 state of the email template has no JSON to upgrade.")
+		return
+	}
+
+	var state map[string]any
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "Could not decode the version 1 state of the email template: "+err.Error())
+		return
+	}
+	for _, name := range []string{"created_at", "updated_at"} {
+		if v, ok := state[name].(string); ok {
+			state[name] = upgradeTimestamp(types.StringValue(v)).ValueString()
+		}
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State", "Could not encode the upgraded state of the email template: "+err.Error())
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// upgradeTimestamp reformats a timestamp in one of legacyTimestampLayouts as
+// RFC 3339. Other values, including RFC 3339 timestamps, are kept, as are
+// timestamps whose zone abbreviation has no known offset.
+func upgradeTimestamp(v types.String) types.String {
+	if v.IsNull() || v.IsUnknown() {
+		return v
+	}
+	if _, err := time.Parse(time.RFC3339, v.ValueString()); err == nil {
+		return v
+	}
+
+	for _, layout := range legacyTimestampLayouts {
+		if t, err := time.Parse(layout, v.ValueString()); err == nil {
+			if unknownZone(t) {
+				return v
+			}
+			return types.StringValue(t.Format(time.RFC3339))
+		}
+	}
+
+	return v
+}
+
+// unknownZone reports whether t was parsed with a zone abbreviation, such as
+// CET on a machine outside that zone, that time.Parse gives a zero offset
+// because it does not know the real one.
+func unknownZone(t time.Time) bool {
+	name, offset := t.Zone()
+	return offset == 0 && name != "" && name != "UTC" && name != "GMT"
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestEmailTemplateResource_UpgradeState(t *testing.T) {
+	cases := map[string]struct {
+		version int64
+		state   string
+		want    map[string]string
+	}{
+		// The state of the resource as first released, with every attribute
+		// of that schema and nothing else.
+		"v0": {
+			version: 0,
+			state: `{
+				"id": "42",
+				"name": "Welcome",
+				"from": "Sender <sender@example.com>",
+				"reply_to": null,
+				"subject": "Welcome aboard",
+				"preheader": "Hello",
+				"html": "<html><body><h1>Hi</h1></body></html>",
+				"is_html_editable": true,
+				"landing_page": "",
+				"image_preview_url": "https://cdn.example.com/preview.png",
+				"created_at": "2024-05-01T10:00:00Z",
+				"updated_at": "Wednesday, 01-May-24 12:30:00 UTC"
+			}`,
+			want: map[string]string{
+				"id":                "42",
+				"name":              "Welcome",
+				"preheader":         "Hello",
+				"image_preview_url": "https://cdn.example.com/preview.png",
+				"created_at":        "2024-05-01T10:00:00Z",
+				"updated_at":        "2024-05-01T12:30:00Z",
+			},
+		},
+		// Version 1 state written before created_at and updated_at were
+		// written as RFC 3339, without the attributes added since.
+		"v1": {
+			version: 1,
+			state: `{
+				"id": "42",
+				"name": "Welcome",
+				"from": "Sender <sender@example.com>",
+				"subject": "Welcome aboard",
+				"html": "<html><body><h1>Hi</h1></body></html>",
+				"is_html_editable": true,
+				"created_at": "Monday, 02-Jan-06 15:04:05 UTC",
+				"updated_at": "2024-05-01T10:00:00Z",
+				"placeholders": []
+			}`,
+			want: map[string]string{
+				"id":         "42",
+				"name":       "Welcome",
+				"created_at": "2006-01-02T15:04:05Z",
+				"updated_at": "2024-05-01T10:00:00Z",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := newTestHarness(t, newMockInfobip(t), nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			if r.schema.Version != emailTemplateSchemaVersion {
+				t.Fatalf("schema version = %d, want %d", r.schema.Version, emailTemplateSchemaVersion)
+			}
+
+			resp, err := h.server.UpgradeResourceState(h.ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: "pocinfobipemails_email_template",
+				Version:  tc.version,
+				RawState: &tfprotov6.RawState{JSON: []byte(tc.state)},
+			})
+			if err != nil {
+				t.Fatalf("UpgradeResourceState: %s", err)
+			}
+			if hasError(resp.Diagnostics) {
+				t.Fatalf("upgrade: %s", diagnosticsString(resp.Diagnostics))
+			}
+
+			r.state = r.unmarshal(resp.UpgradedState)
+			for name, value := range tc.want {
+				if got := r.stringAttr(name); got != value {
+					t.Errorf("upgraded %s = %q, want %q", name, got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestUpgradeTimestamp(t *testing.T) {
+	// Zone abbreviations resolve against the local zone, so keep CET
+	// unknown whatever zone the test runs in.
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	cases := map[string]string{
+		"Monday, 02-Jan-06 15:04:05 UTC":  "2006-01-02T15:04:05Z",
+		"Monday, 02-Jan-06 15:04:05 GMT":  "2006-01-02T15:04:05Z",
+		"Monday, 02-Jan-06 15:04:05 CET":  "Monday, 02-Jan-06 15:04:05 CET",
+		"Mon, 02 Jan 2006 15:04:05 EST":   "Mon, 02 Jan 2006 15:04:05 EST",
+		"Mon, 02 Jan 2006 15:04:05 +0200": "2006-01-02T15:04:05+02:00",
+		"2006-01-02T15:04:05.000+0000":    "2006-01-02T15:04:05Z",
+		"2006-01-02T15:04:05+02:00":       "2006-01-02T15:04:05+02:00",
+		"":                                "",
+		"not a timestamp":                 "not a timestamp",
+	}
+	for in, want := range cases {
+		if got := upgradeTimestamp(types.StringValue(in)).ValueString(); got != want {
+			t.Errorf("upgradeTimestamp(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := upgradeTimestamp(types.StringNull()); !got.IsNull() {
+		t.Errorf("upgradeTimestamp(null) = %s, want null", got)
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// emailTemplatesPageSize is the page size used when listing templates.
+const emailTemplatesPageSize = 100
+
+// emailTemplatesFetchConcurrency bounds the number of concurrent
+// GetEmailTemplate calls made when fetch_full is enabled.
+const emailTemplatesFetchConcurrency = 4
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmailTemplatesDataSource{}
+
+func NewEmailTemplatesDataSource() datasource.DataSource {
+	return &EmailTemplatesDataSource{}
+}
+
+// EmailTemplatesDataSource defines the data source implementation.
+type EmailTemplatesDataSource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+}
+
+// EmailTemplatesDataSourceModel describes the data source data model.
+type EmailTemplatesDataSourceModel struct {
+	FetchFull     types.Bool                         `tfsdk:"fetch_full"`
+	ModifiedSince types.String                       `tfsdk:"modified_since"`
+	Templates     []EmailTemplatesDataSourceTemplate `tfsdk:"templates"`
+}
+
+// EmailTemplatesDataSourceTemplate describes a single listed template.
+type EmailTemplatesDataSourceTemplate struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	From            types.String `tfsdk:"from"`
+	ReplyTo         types.String `tfsdk:"reply_to"`
+	Subject         types.String `tfsdk:"subject"`
+	Preheader       types.String `tfsdk:"preheader"`
+	Html            types.String `tfsdk:"html"`
+	LandingPage     types.String `tfsdk:"landing_page"`
+	ImagePreviewUrl types.String `tfsdk:"image_preview_url"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	UpdatedAt       types.String `tfsdk:"updated_at"`
+}
+
+func (d *EmailTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_templates"
+}
+
+func (d *EmailTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Infobip Email Templates of the account.",
+		Attributes: map[string]schema.Attribute{
+			"fetch_full": schema.BoolAttribute{
+				Description: "Fetch the full details of every template instead of the list summary. " +
+					"Templates are fetched concurrently; templates that fail to load keep their summary attributes and are reported as a warning.",
+				Optional: true,
+			},
+			"modified_since": schema.StringAttribute{
+				Description: "RFC 3339 timestamp, such as `2024-05-01T00:00:00Z`, to only list the templates updated after it, for incremental syncs. " +
+					"The API cannot filter by time, so the full details of every template are fetched, as with `fetch_full`, to read their `updated_at`. " +
+					"Templates whose `updated_at` is missing or invalid are listed too, with a warning, so no change is missed.",
+				Optional: true,
+			},
+			"templates": schema.ListNestedAttribute{
+				Description: "Email templates of the account.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the email template.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the email template.",
+							Computed:    true,
+						},
+						"from": schema.StringAttribute{
+							Description: "Sender email address used in the template. Only populated with fetch_full.",
+							Computed:    true,
+						},
+						"reply_to": schema.StringAttribute{
+							Description: "Reply-to email address for the template. Only populated with fetch_full.",
+							Computed:    true,
+						},
+						"subject": schema.StringAttribute{
+							Description: "Subject line of the email template.",
+							Computed:    true,
+						},
+						"preheader": schema.StringAttribute{
+							Description: "Preheader text shown in email previews. Only populated with fetch_full.",
+							Computed:    true,
+						},
+						"html": schema.StringAttribute{
+							Description: "HTML content of the email template.",
+							Computed:    true,
+						},
+						"landing_page": schema.StringAttribute{
+							Description: "Associated landing page ID, if any. Only populated with fetch_full.",
+							Computed:    true,
+						},
+						"image_preview_url": schema.StringAttribute{
+							Description: "URL of the email template’s image preview. Only populated with fetch_full.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Timestamp when the email template was created. Only populated with fetch_full.",
+							Computed:    true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description: "Timestamp when the email template was last updated. Only populated with fetch_full.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EmailTemplatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.infobipClient = pd.client
+	d.apiKey = pd.apiKey
+	d.authSchemeKey = pd.authSchemeKey
+	d.logRedactPatterns = pd.logRedactPatterns
+	d.correlationID = pd.correlationID
+	d.organizationID = pd.organizationID
+	d.warningsAsErrors = pd.warningsAsErrors
+	d.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+}
+
+func (d *EmailTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = redactLogs(ctx, d.logRedactPatterns)
+	ctx = correlateLogs(ctx, d.correlationID)
+	ctx = collectRateLimits(ctx, d.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(d.warningsAsErrors, &resp.Diagnostics)
+
+	var data EmailTemplatesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var modifiedSince time.Time
+	if !data.ModifiedSince.IsNull() {
+		var err error
+		modifiedSince, err = time.Parse(time.RFC3339, data.ModifiedSince.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("modified_since"),
+				"Invalid Modified Since Timestamp",
+				fmt.Sprintf("modified_since %s must be an RFC 3339 timestamp, such as 2024-05-01T00:00:00Z: %s", data.ModifiedSince.String(), err),
+			)
+			return
+		}
+	}
+
+	auth := infobipAuthContext(ctx, d.apiKey, d.authSchemeKey, d.organizationID)
+
+	items, err := listEmailTemplates(auth, d.infobipClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Email Templates",
+			"An error was encountered while listing email templates: "+err.Error(),
+		)
+		return
+	}
+
+	templates := []EmailTemplatesDataSourceTemplate{}
+	for _, item := range items {
+		templates = append(templates, EmailTemplatesDataSourceTemplate{
+			ID:              types.StringValue(fmt.Sprintf("%d", item.GetId())),
+			Name:            types.StringValue(item.GetName()),
+			From:            types.StringNull(),
+			ReplyTo:         types.StringNull(),
+			Subject:         types.StringValue(item.GetSubject()),
+			Preheader:       types.StringNull(),
+			Html:            types.StringValue(normalizeHTML(item.GetBody())),
+			LandingPage:     types.StringNull(),
+			ImagePreviewUrl: types.StringNull(),
+			CreatedAt:       types.StringNull(),
+			UpdatedAt:       types.StringNull(),
+		})
+	}
+
+	// The list summary has no updated_at, so filtering needs the details.
+	if data.FetchFull.ValueBool() || !data.ModifiedSince.IsNull() {
+		failed, err := d.fetchFull(ctx, auth, templates)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Email Templates",
+				"The read was cancelled while fetching full email template details: "+err.Error(),
+			)
+			return
+		}
+		if len(failed) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Incomplete Email Template Details",
+				fmt.Sprintf("Could not fetch full details for %d email template(s); their summary attributes are returned instead:\n%s",
+					len(failed), strings.Join(failed, "\n")),
+			)
+		}
+	}
+
+	if !data.ModifiedSince.IsNull() {
+		var unknown []string
+		templates, unknown = filterModifiedSince(templates, modifiedSince)
+		if len(unknown) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Unknown Email Template Update Time",
+				fmt.Sprintf("The updated_at of %d email template(s) is missing or invalid, so they are listed whatever modified_since is: %s",
+					len(unknown), strings.Join(unknown, ", ")),
+			)
+		}
+	}
+
+	data.Templates = templates
+	tflog.Trace(ctx, "read email templates data source", map[string]any{"count": len(templates)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// fetchFull replaces each summary in templates with the full template,
+// using a bounded pool of workers. It returns a description of every
+// template that could not be fetched, or the error of auth once it is
+// cancelled, since the templates are then incomplete.
+func (d *EmailTemplatesDataSource) fetchFull(ctx context.Context, auth context.Context, templates []EmailTemplatesDataSourceTemplate) ([]string, error) {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []string
+	)
+
+	indexes := make(chan int)
+	for w := 0; w < emailTemplatesFetchConcurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				var idInt int64
+				if _, err := fmt.Sscanf(templates[i].ID.ValueString(), "%d", &idInt); err != nil {
+					mu.Lock()
+					failed = append(failed, fmt.Sprintf("%s: %s", templates[i].ID.ValueString(), err))
+					mu.Unlock()
+					continue
+				}
+
+				emailTemplate, httpResponse, err := d.infobipClient.
+					EmailAPI.
+					GetEmailTemplate(auth).
+					ID(idInt).
+					Execute()
+
+				tflog.Debug(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+				if err != nil {
+					mu.Lock()
+					failed = append(failed, fmt.Sprintf("%s: %s", templates[i].ID.ValueString(), err))
+					mu.Unlock()
+					continue
+				}
+				if isEmptyEmailTemplate(emailTemplate) {
+					mu.Lock()
+					failed = append(failed, fmt.Sprintf("%s: empty response", templates[i].ID.ValueString()))
+					mu.Unlock()
+					continue
+				}
+
+				// Each worker owns a distinct index, so no lock is needed here.
+				templates[i] = EmailTemplatesDataSourceTemplate{
+					ID:              types.StringValue(fmt.Sprintf("%d", emailTemplate.ID)),
+					Name:            types.StringValue(emailTemplate.Name),
+					From:            types.StringValue(emailTemplate.From),
+					ReplyTo:         types.StringValue(emailTemplate.ReplyTo),
+					Subject:         types.StringValue(emailTemplate.Subject),
+					Preheader:       types.StringValue(emailTemplate.Preheader),
+					Html:            types.StringValue(normalizeHTML(emailTemplate.HTML)),
+					LandingPage:     types.StringValue(emailTemplate.LandingPageID),
+					ImagePreviewUrl: types.StringValue(emailTemplate.ImagePreviewURL),
+					CreatedAt:       types.StringValue(emailTemplate.CreatedAt),
+					UpdatedAt:       types.StringValue(emailTemplate.UpdatedAt),
+				}
+			}
+		}()
+	}
+
+	// Stop handing out templates once the read is cancelled.
+dispatch:
+	for i := range templates {
+		select {
+		case indexes <- i:
+		case <-auth.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := auth.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(failed)
+
+	return failed, nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// listedTemplates returns the templates attribute of the data source state,
+// keyed by template ID.
+func listedTemplates(t *testing.T, state tftypes.Value) map[string]map[string]string {
+	t.Helper()
+
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		t.Fatalf("state As: %s", err)
+	}
+
+	var items []tftypes.Value
+	if err := attrs["templates"].As(&items); err != nil {
+		t.Fatalf("templates As: %s", err)
+	}
+
+	templates := map[string]map[string]string{}
+	for _, item := range items {
+		var fields map[string]tftypes.Value
+		if err := item.As(&fields); err != nil {
+			t.Fatalf("template As: %s", err)
+		}
+
+		values := map[string]string{}
+		for name, v := range fields {
+			var s string
+			if !v.IsNull() {
+				_ = v.As(&s)
+			}
+			values[name] = s
+		}
+		templates[values["id"]] = values
+	}
+
+	return templates
+}
+
+func TestEmailTemplatesDataSource_Summary(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "one", Subject: "One", From: "a@example.com", HTML: "<p>1</p>"})
+	h := newTestHarness(t, mock, nil)
+
+	state, diags := h.readDataSource("pocinfobipemails_email_templates", nil)
+	if hasError(diags) {
+		t.Fatalf("read: %s", diagnosticsString(diags))
+	}
+
+	got := listedTemplates(t, state)["1"]
+	if got["name"] != "one" || got["html"] != "<p>1</p>" {
+		t.Errorf("unexpected summary: %v", got)
+	}
+	if got["from"] != "" {
+		t.Errorf("from = %q, want null without fetch_full", got["from"])
+	}
+}
+
+func TestEmailTemplatesDataSource_FetchFullBoundedConcurrency(t *testing.T) {
+	mock := newMockInfobip(t)
+	const count = 12
+	for i := 0; i < count; i++ {
+		mock.addTemplate(email.CreateEmailTemplateResponse{Name: "tmpl", Subject: "s", From: "a@example.com", HTML: "<p>x</p>"})
+	}
+	h := newTestHarness(t, mock, nil)
+	mock.delay = 20 * time.Millisecond
+	mock.maxInFlight.Store(0)
+
+	state, diags := h.readDataSource("pocinfobipemails_email_templates", map[string]tftypes.Value{
+		"fetch_full": tfBool(true),
+	})
+	if hasError(diags) {
+		t.Fatalf("read: %s", diagnosticsString(diags))
+	}
+
+	templates := listedTemplates(t, state)
+	if len(templates) != count {
+		t.Fatalf("got %d templates, want %d", len(templates), count)
+	}
+	for id, tmpl := range templates {
+		if tmpl["from"] != "a@example.com" {
+			t.Errorf("template %s from = %q, want full details", id, tmpl["from"])
+		}
+	}
+
+	if peak := mock.maxInFlight.Load(); peak > emailTemplatesFetchConcurrency {
+		t.Errorf("peak concurrent requests = %d, want at most %d", peak, emailTemplatesFetchConcurrency)
+	} else if peak < 2 {
+		t.Errorf("peak concurrent requests = %d, want requests to run concurrently", peak)
+	}
+}
+
+func TestEmailTemplatesDataSource_FetchFullPartialFailure(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "ok", Subject: "s", From: "a@example.com"})
+	broken := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "broken", Subject: "s", From: "b@example.com"})
+	mock.failGet[broken] = true
+	h := newTestHarness(t, mock, nil)
+
+	state, diags := h.readDataSource("pocinfobipemails_email_templates", map[string]tftypes.Value{
+		"fetch_full": tfBool(true),
+	})
+	if hasError(diags) {
+		t.Fatalf("read: %s", diagnosticsString(diags))
+	}
+	if len(diags) != 1 || diags[0].Severity != tfprotov6.DiagnosticSeverityWarning || !strings.Contains(diags[0].Detail, "2:") {
+		t.Errorf("expected a single warning naming template 2, got: %s", diagnosticsString(diags))
+	}
+
+	templates := listedTemplates(t, state)
+	if templates["1"]["from"] != "a@example.com" {
+		t.Errorf("template 1 from = %q, want full details", templates["1"]["from"])
+	}
+	if templates["2"]["name"] != "broken" || templates["2"]["from"] != "" {
+		t.Errorf("template 2 = %v, want summary only", templates["2"])
+	}
+}
+
+func TestEmailTemplatesDataSource_FetchFullCancelled(t *testing.T) {
+	mock := newMockInfobip(t)
+	for i := 0; i < 12; i++ {
+		mock.addTemplate(email.CreateEmailTemplateResponse{Name: "tmpl", Subject: "s", From: "a@example.com"})
+	}
+	h := newTestHarness(t, mock, nil)
+
+	// Cancel the read as soon as the first template details are requested.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h.ctx = ctx
+	mock.onRequest = func(r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/email/1/templates/") {
+			cancel()
+		}
+	}
+
+	_, diags := h.readDataSource("pocinfobipemails_email_templates", map[string]tftypes.Value{
+		"fetch_full": tfBool(true),
+	})
+	if !hasError(diags) || !strings.Contains(diagnosticsString(diags), context.Canceled.Error()) {
+		t.Errorf("diagnostics = %s, want a context canceled error", diagnosticsString(diags))
+	}
+}
+
+func TestEmailTemplatesDataSource_ModifiedSince(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "old", UpdatedAt: "2023-12-31T23:59:59Z"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "recent", UpdatedAt: "2024-06-01T10:00:00+02:00"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "legacy format", UpdatedAt: "2024-06-02T10:00:00.000+0000"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "missing"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "invalid", UpdatedAt: "yesterday"})
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "exactly at", UpdatedAt: "2024-01-01T00:00:00Z"})
+	h := newTestHarness(t, mock, nil)
+
+	state, diags := h.readDataSource("pocinfobipemails_email_templates", map[string]tftypes.Value{
+		"modified_since": tfString("2024-01-01T00:00:00Z"),
+	})
+	if hasError(diags) {
+		t.Fatalf("read: %s", diagnosticsString(diags))
+	}
+
+	var names []string
+	for _, tmpl := range listedTemplates(t, state) {
+		names = append(names, tmpl["name"])
+	}
+	sort.Strings(names)
+	if want := []string{"invalid", "legacy format", "missing", "recent"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("listed = %v, want %v", names, want)
+	}
+	if !strings.Contains(diagnosticsString(diags), "Unknown Email Template Update Time") || !strings.Contains(diagnosticsString(diags), "4, 5") {
+		t.Errorf("diagnostics = %s, want a warning for templates 4 and 5", diagnosticsString(diags))
+	}
+
+	_, diags = h.readDataSource("pocinfobipemails_email_templates", map[string]tftypes.Value{
+		"modified_since": tfString("2024-01-01"),
+	})
+	if !strings.Contains(diagnosticsString(diags), "Invalid Modified Since Timestamp") {
+		t.Errorf("diagnostics = %s, want Invalid Modified Since Timestamp", diagnosticsString(diags))
+	}
+}
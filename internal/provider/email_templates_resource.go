@@ -0,0 +1,556 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &EmailTemplatesResource{}
+	_ resource.ResourceWithModifyPlan = &EmailTemplatesResource{}
+)
+
+func NewEmailTemplatesResource() resource.Resource {
+	return &EmailTemplatesResource{}
+}
+
+// EmailTemplatesResource manages a set of email templates, keyed by name, in
+// a single resource. Every template is created, updated and deleted on its
+// own, so a failing template does not stop the others; it is reported as an
+// error and retried by the next apply.
+type EmailTemplatesResource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+	// deleteGuard refuses unconfirmed bulk deletes.
+	deleteGuard *deleteGuard
+	// deadline stops API calls once the global deadline has passed.
+	deadline *apiDeadline
+}
+
+// EmailTemplatesResourceModel describes the resource data model.
+type EmailTemplatesResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Templates types.Map    `tfsdk:"templates"`
+}
+
+// emailTemplatesItemModel describes a template of the templates map. Its
+// name is the map key.
+type emailTemplatesItemModel struct {
+	ID      types.String `tfsdk:"id"`
+	From    types.String `tfsdk:"from"`
+	Subject types.String `tfsdk:"subject"`
+	Html    types.String `tfsdk:"html"`
+}
+
+// emailTemplatesItemType is the object type of the templates map elements.
+var emailTemplatesItemType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":      types.StringType,
+	"from":    types.StringType,
+	"subject": types.StringType,
+	"html":    types.StringType,
+}}
+
+func (r *EmailTemplatesResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_templates"
+}
+
+func (r *EmailTemplatesResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of Infobip Email Templates in a single resource, such as templates loaded from a folder of files. " +
+			"Templates added to, changed in or removed from `templates` are created, updated or deleted one by one. " +
+			"A template that fails is reported as an error without stopping the others, and is retried by the next apply. " +
+			"If templates fail on the first apply, Terraform marks the whole resource as tainted and replaces it on the next apply. " +
+			"The reply-to address, preheader and landing page of the templates are left empty.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the set of templates.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"templates": schema.MapNestedAttribute{
+				Description: "Templates to manage, keyed by template name.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the email template. Unset while the template has not been created.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"from": schema.StringAttribute{
+							Description: "Sender email address of the template.",
+							Required:    true,
+						},
+						"subject": schema.StringAttribute{
+							Description: "Subject line of the template. Must not be empty.",
+							Required:    true,
+							Validators: []validator.String{
+								emptySubjectValidator{},
+							},
+						},
+						"html": schema.StringAttribute{
+							Description: "HTML content of the template. Whitespace-only changes are ignored.",
+							Required:    true,
+							Validators: []validator.String{
+								htmlControlCharactersValidator{},
+							},
+							PlanModifiers: []planmodifier.String{
+								htmlWhitespaceInsensitiveModifier{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *EmailTemplatesResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.infobipClient = pd.client
+	r.apiKey = pd.apiKey
+	r.authSchemeKey = pd.authSchemeKey
+	r.logRedactPatterns = pd.logRedactPatterns
+	r.correlationID = pd.correlationID
+	r.organizationID = pd.organizationID
+	r.warningsAsErrors = pd.warningsAsErrors
+	r.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+	r.deleteGuard = pd.deleteGuard
+	r.deadline = pd.deadline
+}
+
+// ModifyPlan plans the creation of templates that failed to be created, or
+// were deleted outside of Terraform, by a previous apply.
+func (r *EmailTemplatesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state EmailTemplatesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Templates.IsUnknown() {
+		return
+	}
+
+	planned, diags := emailTemplatesItems(ctx, plan.Templates)
+	resp.Diagnostics.Append(diags...)
+	stored, diags := emailTemplatesItems(ctx, state.Templates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, item := range planned {
+		if prior, ok := stored[name]; ok && prior.ID.IsNull() {
+			item.ID = types.StringUnknown()
+			planned[name] = item
+		}
+	}
+
+	plan.Templates, diags = types.MapValueFrom(ctx, emailTemplatesItemType, planned)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *EmailTemplatesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var plan EmailTemplatesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := emailTemplatesItems(ctx, plan.Templates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Generate ID",
+			"An unexpected error occurred while generating the ID of the set of email templates: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+	for _, name := range sortedKeys(planned) {
+		planned[name] = r.createItem(ctx, auth, name, planned[name], &resp.Diagnostics)
+	}
+
+	plan.Templates, diags = types.MapValueFrom(ctx, emailTemplatesItemType, planned)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *EmailTemplatesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var state EmailTemplatesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, diags := emailTemplatesItems(ctx, state.Templates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+	for _, name := range sortedKeys(stored) {
+		item := stored[name]
+		var id int64
+		if _, err := fmt.Sscanf(item.ID.ValueString(), "%d", &id); err != nil {
+			continue
+		}
+
+		if diags := r.deadline.check("read email template ID " + item.ID.String()); diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			break
+		}
+		emailTemplate, httpResponse, err := r.infobipClient.
+			EmailAPI.
+			GetEmailTemplate(auth).
+			ID(id).
+			Execute()
+
+		tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+		// A template deleted outside of Terraform is planned to be created
+		// again.
+		if (err == nil && isEmptyEmailTemplate(emailTemplate)) || (httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound) {
+			tflog.Warn(ctx, "Email template not found; removing from the set", map[string]any{"name": name, "id": item.ID.ValueString()})
+			delete(stored, name)
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("templates").AtMapKey(name),
+				"Error Reading Email Template",
+				fmt.Sprintf("Could not read email template %q, ID %s: %s", name, item.ID.ValueString(), err),
+			)
+			continue
+		}
+
+		stored[name] = setEmailTemplatesItem(item, emailTemplate)
+	}
+
+	state.Templates, diags = types.MapValueFrom(ctx, emailTemplatesItemType, stored)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EmailTemplatesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var plan, state EmailTemplatesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, diags := emailTemplatesItems(ctx, plan.Templates)
+	resp.Diagnostics.Append(diags...)
+	stored, diags := emailTemplatesItems(ctx, state.Templates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+
+	// Templates removed from the map are deleted. Those that fail to be
+	// deleted stay in state, so the next apply deletes them again.
+	for _, name := range sortedKeys(stored) {
+		if _, ok := planned[name]; ok {
+			continue
+		}
+		if !r.deleteItem(ctx, auth, name, stored[name], &resp.Diagnostics) {
+			planned[name] = stored[name]
+		}
+	}
+
+	for _, name := range sortedKeys(planned) {
+		item, prior := planned[name], stored[name]
+		switch {
+		case prior.ID.IsNull():
+			planned[name] = r.createItem(ctx, auth, name, item, &resp.Diagnostics)
+		case item.ID.IsUnknown() || !item.From.Equal(prior.From) || !item.Subject.Equal(prior.Subject) || !item.Html.Equal(prior.Html):
+			planned[name] = r.updateItem(ctx, auth, name, item, prior, &resp.Diagnostics)
+		}
+	}
+
+	plan.Templates, diags = types.MapValueFrom(ctx, emailTemplatesItemType, planned)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *EmailTemplatesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var state EmailTemplatesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stored, diags := emailTemplatesItems(ctx, state.Templates)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+	for _, name := range sortedKeys(stored) {
+		if r.deleteItem(ctx, auth, name, stored[name], &resp.Diagnostics) {
+			delete(stored, name)
+		}
+	}
+
+	// Keep the templates that could not be deleted, so destroying again
+	// retries them.
+	if len(stored) > 0 {
+		state.Templates, diags = types.MapValueFrom(ctx, emailTemplatesItemType, stored)
+		resp.Diagnostics.Append(diags...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// createItem creates the template name and returns item with its ID. On
+// failure, the error is added to diags and item is returned without an ID,
+// so the next apply creates it again.
+func (r *EmailTemplatesResource) createItem(ctx context.Context, auth context.Context, name string, item emailTemplatesItemModel, diags *diag.Diagnostics) emailTemplatesItemModel {
+	item.ID = types.StringNull()
+
+	if d := r.deadline.check(fmt.Sprintf("create email template %q", name)); d.HasError() {
+		diags.Append(d...)
+		return item
+	}
+
+	emailTemplate, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		CreateEmailTemplate(auth).
+		Name(name).
+		From(item.From.ValueString()).
+		ReplyTo("").
+		Subject(item.Subject.ValueString()).
+		Preheader("").
+		Html(item.Html.ValueString()).
+		LandingPage("").
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err == nil && isEmptyEmailTemplate(emailTemplate) {
+		err = fmt.Errorf("Infobip returned template ID %d, which is not a valid ID", emailTemplateID(emailTemplate))
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("templates").AtMapKey(name),
+			"Error Creating Email Template",
+			fmt.Sprintf("An error was encountered while creating email template %q: %s", name, err),
+		)
+		return item
+	}
+	tflog.Info(ctx, "Created email template", map[string]any{"name": name, "id": emailTemplate.ID})
+
+	return setEmailTemplatesItem(item, emailTemplate)
+}
+
+// updateItem sends item to the template name. On failure, the error is added
+// to diags and prior is returned, so the next apply updates it again.
+func (r *EmailTemplatesResource) updateItem(ctx context.Context, auth context.Context, name string, item, prior emailTemplatesItemModel, diags *diag.Diagnostics) emailTemplatesItemModel {
+	var id int64
+	if _, err := fmt.Sscanf(prior.ID.ValueString(), "%d", &id); err != nil {
+		return prior
+	}
+
+	if d := r.deadline.check("update email template ID " + prior.ID.String()); d.HasError() {
+		diags.Append(d...)
+		return prior
+	}
+
+	emailTemplate, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		UpdateEmailTemplate(auth).
+		ID(id).
+		Name(name).
+		From(item.From.ValueString()).
+		ReplyTo("").
+		Subject(item.Subject.ValueString()).
+		Preheader("").
+		Html(item.Html.ValueString()).
+		LandingPage("").
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err == nil && isEmptyEmailTemplate(emailTemplate) {
+		err = fmt.Errorf("Infobip returned template ID %d, which is not a valid ID", emailTemplateID(emailTemplate))
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("templates").AtMapKey(name),
+			"Error Updating Email Template",
+			fmt.Sprintf("An error was encountered while updating email template %q, ID %s: %s", name, prior.ID.ValueString(), err),
+		)
+		return prior
+	}
+
+	return setEmailTemplatesItem(item, emailTemplate)
+}
+
+// deleteItem deletes the template name, treating a missing template as
+// deleted. It reports whether the template is gone; on failure, the error is
+// added to diags.
+func (r *EmailTemplatesResource) deleteItem(ctx context.Context, auth context.Context, name string, item emailTemplatesItemModel, diags *diag.Diagnostics) bool {
+	var id int64
+	if _, err := fmt.Sscanf(item.ID.ValueString(), "%d", &id); err != nil {
+		// Never created.
+		return true
+	}
+
+	if err := r.deleteGuard.allow(); err != nil {
+		diags.AddAttributeError(
+			path.Root("templates").AtMapKey(name),
+			"Bulk Delete Not Confirmed",
+			fmt.Sprintf("Could not delete email template %q, ID %s: %s", name, item.ID.ValueString(), err),
+		)
+		return false
+	}
+	if d := r.deadline.check("delete email template ID " + item.ID.String()); d.HasError() {
+		diags.Append(d...)
+		return false
+	}
+
+	httpResponse, err := r.infobipClient.
+		EmailAPI.
+		RemoveEmailTemplate(auth).
+		ID(id).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err != nil && (httpResponse == nil || httpResponse.StatusCode != http.StatusNotFound) {
+		diags.AddAttributeError(
+			path.Root("templates").AtMapKey(name),
+			"Error Deleting Email Template",
+			fmt.Sprintf("An error was encountered while deleting email template %q, ID %s: %s", name, item.ID.ValueString(), err),
+		)
+		return false
+	}
+
+	return true
+}
+
+// emailTemplatesItems decodes the templates map.
+func emailTemplatesItems(ctx context.Context, templates types.Map) (map[string]emailTemplatesItemModel, diag.Diagnostics) {
+	items := map[string]emailTemplatesItemModel{}
+	if templates.IsNull() || templates.IsUnknown() {
+		return items, nil
+	}
+
+	diags := templates.ElementsAs(ctx, &items, false)
+
+	return items, diags
+}
+
+// setEmailTemplatesItem maps an API template to item. The html is kept as
+// planned when it only differs from the stored html by whitespace, like the
+// email template resource does.
+func setEmailTemplatesItem(item emailTemplatesItemModel, emailTemplate *email.CreateEmailTemplateResponse) emailTemplatesItemModel {
+	item.ID = types.StringValue(fmt.Sprintf("%d", emailTemplate.ID))
+	item.From = types.StringValue(emailTemplate.From)
+	item.Subject = types.StringValue(emailTemplate.Subject)
+	if normalizeHTML(item.Html.ValueString()) != normalizeHTML(emailTemplate.HTML) {
+		item.Html = types.StringValue(emailTemplate.HTML)
+	}
+
+	return item
+}
+
+// sortedKeys returns the keys of m in order, so templates are processed in a
+// stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
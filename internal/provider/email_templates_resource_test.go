@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// emailTemplatesItemTFType is the type of the templates map elements.
+var emailTemplatesItemTFType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"id":      tftypes.String,
+	"from":    tftypes.String,
+	"subject": tftypes.String,
+	"html":    tftypes.String,
+}}
+
+// testEmailTemplatesConfig returns a configuration of the templates map,
+// with a template per name and the given subject.
+func testEmailTemplatesConfig(subjects map[string]string) map[string]tftypes.Value {
+	items := map[string]tftypes.Value{}
+	for name, subject := range subjects {
+		items[name] = tftypes.NewValue(emailTemplatesItemTFType, map[string]tftypes.Value{
+			"id":      tftypes.NewValue(tftypes.String, nil),
+			"from":    tfString("Sender <sender@example.com>"),
+			"subject": tfString(subject),
+			"html":    tfString("<html><body><h1>" + name + "</h1></body></html>"),
+		})
+	}
+
+	return map[string]tftypes.Value{
+		"templates": tftypes.NewValue(tftypes.Map{ElementType: emailTemplatesItemTFType}, items),
+	}
+}
+
+// templateIDs returns the template ID of every name in the templates map of
+// the state.
+func (r *testResource) templateIDs() map[string]string {
+	r.h.t.Helper()
+
+	var items map[string]tftypes.Value
+	if err := r.attr("templates").As(&items); err != nil {
+		r.h.t.Fatalf("templates As: %s", err)
+	}
+
+	ids := map[string]string{}
+	for name, item := range items {
+		var attrs map[string]tftypes.Value
+		_ = item.As(&attrs)
+		var id string
+		if !attrs["id"].IsNull() {
+			_ = attrs["id"].As(&id)
+		}
+		ids[name] = id
+	}
+
+	return ids
+}
+
+// templateSubjects returns the subject of every template in the mock,
+// keyed by name.
+func (m *mockInfobip) templateSubjects() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subjects := map[string]string{}
+	for _, tmpl := range m.templates {
+		subjects[tmpl.Name] = tmpl.Subject
+	}
+
+	return subjects
+}
+
+func TestEmailTemplatesResource(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_templates")
+
+	if diags := r.apply(testEmailTemplatesConfig(map[string]string{"welcome": "Welcome", "reset": "Reset"})); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	created := r.templateIDs()
+	if len(created) != 2 || created["welcome"] == "" || created["reset"] == "" {
+		t.Fatalf("template IDs = %v, want one per template", created)
+	}
+	if got := mock.templateSubjects(); len(got) != 2 || got["welcome"] != "Welcome" || got["reset"] != "Reset" {
+		t.Errorf("templates = %v, want welcome and reset", got)
+	}
+
+	// Add, change and remove one template each.
+	if diags := r.apply(testEmailTemplatesConfig(map[string]string{"reset": "Reset your password", "invite": "Invite"})); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	updated := r.templateIDs()
+	if updated["reset"] != created["reset"] || updated["invite"] == "" || len(updated) != 2 {
+		t.Errorf("template IDs = %v, want reset kept as %s and invite added", updated, created["reset"])
+	}
+	if got := mock.templateSubjects(); len(got) != 2 || got["reset"] != "Reset your password" || got["invite"] != "Invite" {
+		t.Errorf("templates = %v, want reset changed, invite added and welcome deleted", got)
+	}
+
+	if diags := r.destroy(); hasError(diags) {
+		t.Fatalf("destroy: %s", diagnosticsString(diags))
+	}
+	if got := mock.templateSubjects(); len(got) != 0 {
+		t.Errorf("templates = %v, want all deleted", got)
+	}
+}
+
+func TestEmailTemplatesResource_ItemFailure(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_templates")
+
+	if diags := r.apply(testEmailTemplatesConfig(map[string]string{"welcome": "Welcome", "reset": "Reset"})); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	ids := r.templateIDs()
+
+	// A failing update does not stop the others, and is retried.
+	mock.mu.Lock()
+	mock.failPut[mustParseID(t, ids["reset"])] = 1
+	mock.mu.Unlock()
+	config := testEmailTemplatesConfig(map[string]string{"welcome": "Welcome!", "reset": "Reset!", "invite": "Invite"})
+	diags := r.apply(config)
+	if !strings.Contains(diagnosticsString(diags), `updating email template "reset"`) {
+		t.Fatalf("diagnostics = %s, want the reset update error", diagnosticsString(diags))
+	}
+	if got := mock.templateSubjects(); got["welcome"] != "Welcome!" || got["invite"] != "Invite" {
+		t.Errorf("templates = %v, want welcome updated and invite created", got)
+	}
+
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("retry: %s", diagnosticsString(diags))
+	}
+	if got := mock.templateSubjects(); got["reset"] != "Reset!" {
+		t.Errorf("templates = %v, want reset updated by the retry", got)
+	}
+
+	// A template deleted outside of Terraform is created again.
+	mock.mu.Lock()
+	delete(mock.templates, mustParseID(t, ids["welcome"]))
+	mock.mu.Unlock()
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	if _, ok := r.templateIDs()["welcome"]; ok {
+		t.Errorf("template IDs = %v, want welcome removed by the refresh", r.templateIDs())
+	}
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("apply: %s", diagnosticsString(diags))
+	}
+	names := make([]string, 0, len(mock.templateSubjects()))
+	for name := range mock.templateSubjects() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "invite,reset,welcome" {
+		t.Errorf("templates = %v, want invite, reset and welcome", names)
+	}
+	if id := r.templateIDs()["welcome"]; id == "" || id == ids["welcome"] {
+		t.Errorf("welcome ID = %q, want a new template", id)
+	}
+}
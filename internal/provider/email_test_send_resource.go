@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource = &EmailTestSendResource{}
+	_ validator.List    = recipientAddressesValidator{}
+)
+
+func NewEmailTestSendResource() resource.Resource {
+	return &EmailTestSendResource{}
+}
+
+// EmailTestSendResource sends an email template to test recipients when it
+// is created. Every attribute forces a new send when changed; destroying it
+// only removes it from state, since a sent email cannot be recalled.
+type EmailTestSendResource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+	// deadline stops API calls once the global deadline has passed.
+	deadline *apiDeadline
+}
+
+// EmailTestSendResourceModel describes the resource data model.
+type EmailTestSendResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	TemplateID types.String `tfsdk:"template_id"`
+	To         types.List   `tfsdk:"to"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+	MessageIDs types.Map    `tfsdk:"message_ids"`
+}
+
+func (r *EmailTestSendResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_test_send"
+}
+
+func (r *EmailTestSendResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends an Infobip Email Template to test recipients, such as a QA mailbox. " +
+			"**This sends real emails.** The template is sent once, when the resource is created; changing any attribute sends it again, " +
+			"and destroying the resource only removes it from state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Bulk ID of the send, as reported by Infobip.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"template_id": schema.StringAttribute{
+				Description: "ID of the email template to send, such as the `id` of a `pocinfobipemails_email_template`, so the template is sent again when it is replaced.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"to": schema.ListAttribute{
+				Description: "Email addresses to send the template to, such as `qa@example.com`. Display names are not accepted.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					recipientAddressesValidator{},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that send the template again when they change, such as the `updated_at` of the template, to send every new version.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"message_ids": schema.MapAttribute{
+				Description: "ID of the message sent to every address in `to`, keyed by address, to look up its delivery.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *EmailTestSendResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.infobipClient = pd.client
+	r.apiKey = pd.apiKey
+	r.authSchemeKey = pd.authSchemeKey
+	r.logRedactPatterns = pd.logRedactPatterns
+	r.correlationID = pd.correlationID
+	r.organizationID = pd.organizationID
+	r.warningsAsErrors = pd.warningsAsErrors
+	r.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+	r.deadline = pd.deadline
+}
+
+func (r *EmailTestSendResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = redactLogs(ctx, r.logRedactPatterns)
+	ctx = correlateLogs(ctx, r.correlationID)
+	ctx = collectRateLimits(ctx, r.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(r.warningsAsErrors, &resp.Diagnostics)
+
+	var plan EmailTestSendResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var templateID int64
+	if _, err := fmt.Sscanf(plan.TemplateID.ValueString(), "%d", &templateID); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("template_id"),
+			"Invalid Email Template ID",
+			fmt.Sprintf("template_id %s is not a valid email template ID.", plan.TemplateID.String()),
+		)
+		return
+	}
+
+	var to []string
+	resp.Diagnostics.Append(plan.To.ElementsAs(ctx, &to, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.deadline.check("send email template ID " + plan.TemplateID.String())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	auth := infobipAuthContext(ctx, r.apiKey, r.authSchemeKey, r.organizationID)
+
+	sent, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		SendEmail(auth).
+		TemplateId(templateID).
+		To(to).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Sending Email Template",
+			fmt.Sprintf("An error was encountered while sending email template ID %d: %s", templateID, err),
+		)
+		return
+	}
+
+	messageIDs := map[string]string{}
+	for _, message := range sent.Messages {
+		if message.To != nil && message.MessageId != nil {
+			messageIDs[*message.To] = *message.MessageId
+		}
+	}
+	tflog.Info(ctx, "Sent email template to test recipients", map[string]any{"template_id": templateID, "recipients": len(to)})
+
+	bulkID := ""
+	if sent.BulkId != nil {
+		bulkID = *sent.BulkId
+	}
+	plan.ID = types.StringValue(bulkID)
+	plan.MessageIDs, _ = types.MapValueFrom(ctx, types.StringType, messageIDs)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read keeps the state: a send is not a remote object that can drift.
+func (r *EmailTestSendResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update only stores the plan: every attribute that can change forces a new
+// send, so there is nothing to send on update.
+func (r *EmailTestSendResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan EmailTestSendResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the send from state, since a sent email cannot be
+// recalled.
+func (r *EmailTestSendResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.RemoveResource(ctx)
+}
+
+// recipientAddressesValidator requires a non-empty list of bare email
+// addresses, such as "qa@example.com".
+type recipientAddressesValidator struct{}
+
+func (v recipientAddressesValidator) Description(ctx context.Context) string {
+	return "Value must be a non-empty list of email addresses."
+}
+
+func (v recipientAddressesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v recipientAddressesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	elements := req.ConfigValue.Elements()
+	if len(elements) == 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Missing Recipients", "At least one email address is required.")
+		return
+	}
+
+	for i, element := range elements {
+		address, ok := element.(types.String)
+		if !ok || address.IsNull() || address.IsUnknown() {
+			continue
+		}
+
+		if parsed, err := mail.ParseAddress(address.ValueString()); err != nil || parsed.Address != address.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Invalid Recipient Address",
+				fmt.Sprintf("%s is not an email address such as qa@example.com.", address.String()),
+			)
+		}
+	}
+}
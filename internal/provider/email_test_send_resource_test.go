@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func tfStringList(values ...string) tftypes.Value {
+	elements := make([]tftypes.Value, len(values))
+	for i, v := range values {
+		elements[i] = tfString(v)
+	}
+
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+}
+
+func TestEmailTestSendResource(t *testing.T) {
+	mock := newMockInfobip(t)
+	id := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Welcome", Subject: "Hi"})
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_test_send")
+
+	config := map[string]tftypes.Value{
+		"template_id": tfString(fmt.Sprintf("%d", id)),
+		"to":          tfStringList("qa@example.com", "design@example.com"),
+	}
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	if len(mock.sends) != 1 {
+		t.Fatalf("sends = %d, want 1", len(mock.sends))
+	}
+	sent := mock.sends[0]
+	if !reflect.DeepEqual(sent["to"], []string{"qa@example.com", "design@example.com"}) || first(sent["templateId"]) != fmt.Sprintf("%d", id) {
+		t.Errorf("sent form = %v, want the template sent to both addresses", sent)
+	}
+	if got := r.stringAttr("id"); got != "bulk-1" {
+		t.Errorf("id = %q, want the bulk ID", got)
+	}
+	var messageIDs map[string]tftypes.Value
+	_ = r.attr("message_ids").As(&messageIDs)
+	if len(messageIDs) != 2 || !messageIDs["qa@example.com"].Equal(tfString("bulk-1-0")) {
+		t.Errorf("message_ids = %v, want one per address", messageIDs)
+	}
+
+	// Applying and refreshing an unchanged configuration does not send again.
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("apply: %s", diagnosticsString(diags))
+	}
+	if len(mock.sends) != 1 {
+		t.Errorf("sends after an unchanged apply = %d, want 1", len(mock.sends))
+	}
+
+	// A changed trigger sends again.
+	config["triggers"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"version": tfString("2")})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("apply: %s", diagnosticsString(diags))
+	}
+	if len(mock.sends) != 2 || r.replaced != 1 {
+		t.Errorf("sends = %d, replaced = %d, want a second send", len(mock.sends), r.replaced)
+	}
+
+	// Destroying does not call the API.
+	requests := len(mock.requests)
+	if diags := r.destroy(); hasError(diags) {
+		t.Fatalf("destroy: %s", diagnosticsString(diags))
+	}
+	if len(mock.requests) != requests {
+		t.Errorf("requests on destroy = %v, want none", mock.requests[requests:])
+	}
+}
+
+func TestEmailTestSendResource_UnknownTemplate(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_test_send")
+
+	diags := r.apply(map[string]tftypes.Value{"template_id": tfString("42"), "to": tfStringList("qa@example.com")})
+	if !strings.Contains(diagnosticsString(diags), "Error Sending Email Template") {
+		t.Errorf("diagnostics = %s, want Error Sending Email Template", diagnosticsString(diags))
+	}
+}
+
+func TestEmailTestSendResource_InvalidRecipients(t *testing.T) {
+	h := newTestHarness(t, newMockInfobip(t), nil)
+	r := h.resource("pocinfobipemails_email_test_send")
+
+	cases := map[string]struct {
+		to   tftypes.Value
+		want string
+	}{
+		"empty":        {to: tfStringList(), want: "Missing Recipients"},
+		"not an email": {to: tfStringList("qa@example.com", "qa"), want: "Invalid Recipient Address"},
+		"display name": {to: tfStringList("QA <qa@example.com>"), want: "Invalid Recipient Address"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := r.validate(map[string]tftypes.Value{"template_id": tfString("1"), "to": tc.to})
+			if !strings.Contains(diagnosticsString(diags), tc.want) {
+				t.Errorf("diagnostics = %s, want %s", diagnosticsString(diags), tc.want)
+			}
+		})
+	}
+
+	if diags := r.validate(map[string]tftypes.Value{"template_id": tfString("1"), "to": tfStringList("qa@example.com")}); hasError(diags) {
+		t.Errorf("validate: %s", diagnosticsString(diags))
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// externalLinterTimeout bounds a single run of the external linter.
+var externalLinterTimeout = 30 * time.Second
+
+// externalLinterOutputMaxBytes caps the linter output shown in diagnostics.
+const externalLinterOutputMaxBytes = 4 << 10
+
+// externalLinter runs an html email linter command with the html on stdin.
+// A nonzero exit status means the html failed the lint, and the combined
+// output explains why.
+type externalLinter struct {
+	// args is the command and its arguments; no shell is involved.
+	args []string
+	// errorSeverity reports failures as errors instead of warnings.
+	errorSeverity bool
+}
+
+// check runs the linter on html. Failures are reported as warnings or errors
+// on the html attribute, depending on the configured severity. A linter that
+// cannot be run, such as a missing binary, is reported as a warning only, so
+// it does not block plans on machines without it.
+func (l *externalLinter) check(ctx context.Context, html string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if l == nil || len(l.args) == 0 {
+		return diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, externalLinterTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, l.args[0], l.args[1:]...)
+	cmd.Stdin = strings.NewReader(html)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	if err == nil {
+		return diags
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || ctx.Err() != nil {
+		diags.AddAttributeWarning(
+			path.Root("html"),
+			"External Linter Unavailable",
+			fmt.Sprintf("Could not run the external linter %q, so the html was not checked: %s", l.args[0], err),
+		)
+		return diags
+	}
+
+	detail := fmt.Sprintf("The external linter %q exited with status %d:\n%s", l.args[0], exitErr.ExitCode(), truncateLinterOutput(output.String()))
+	if l.errorSeverity {
+		diags.AddAttributeError(path.Root("html"), "External HTML Lint Failed", detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("html"), "External HTML Lint Failed", detail)
+	}
+
+	return diags
+}
+
+// truncateLinterOutput trims output and caps it at
+// externalLinterOutputMaxBytes.
+func truncateLinterOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) <= externalLinterOutputMaxBytes {
+		return output
+	}
+
+	return output[:externalLinterOutputMaxBytes] + "\n(output truncated)"
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestExternalLinterCheck(t *testing.T) {
+	cases := map[string]struct {
+		linter   *externalLinter
+		warnings []string
+		errors   []string
+	}{
+		"passing": {
+			linter: &externalLinter{args: []string{"sh", "-c", `grep -q "<h1>"`}},
+		},
+		"failing": {
+			linter:   &externalLinter{args: []string{"sh", "-c", `cat >/dev/null; echo "img is missing alt"; exit 3`}},
+			warnings: []string{"exited with status 3:\nimg is missing alt"},
+		},
+		"failing as error": {
+			linter: &externalLinter{args: []string{"sh", "-c", `echo "img is missing alt" >&2; exit 1`}, errorSeverity: true},
+			errors: []string{"img is missing alt"},
+		},
+		"missing binary": {
+			linter:   &externalLinter{args: []string{"/nonexistent/html-email-lint"}, errorSeverity: true},
+			warnings: []string{"Could not run the external linter"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := tc.linter.check(context.Background(), "<html><body><h1>Hi</h1></body></html>")
+
+			if got := diags.WarningsCount(); got != len(tc.warnings) {
+				t.Errorf("got %d warnings %v, want %v", got, diags, tc.warnings)
+			}
+			for i, d := range diags.Warnings() {
+				if i < len(tc.warnings) && !strings.Contains(d.Detail(), tc.warnings[i]) {
+					t.Errorf("warning %d = %q, want %q", i, d.Detail(), tc.warnings[i])
+				}
+			}
+			if got := diags.ErrorsCount(); got != len(tc.errors) {
+				t.Errorf("got %d errors %v, want %v", got, diags, tc.errors)
+			}
+			for i, d := range diags.Errors() {
+				if i < len(tc.errors) && !strings.Contains(d.Detail(), tc.errors[i]) {
+					t.Errorf("error %d = %q, want %q", i, d.Detail(), tc.errors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_ExternalLinter(t *testing.T) {
+	failing := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tfString("sh"), tfString("-c"), tfString(`cat >/dev/null; echo "img is missing alt"; exit 1`),
+	})
+
+	for _, severity := range []string{"warning", "error"} {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, map[string]tftypes.Value{
+			"external_linter_cmd":      failing,
+			"external_linter_severity": tfString(severity),
+		})
+		r := h.resource("pocinfobipemails_email_template")
+
+		diags := r.apply(testEmailTemplateConfig(nil))
+		if hasError(diags) != (severity == "error") {
+			t.Fatalf("severity %s: diagnostics = %s", severity, diagnosticsString(diags))
+		}
+		if !strings.Contains(diagnosticsString(diags), "img is missing alt") {
+			t.Errorf("severity %s: diagnostics = %s, want the linter output", severity, diagnosticsString(diags))
+		}
+		if created := len(mock.templates) == 1; created != (severity == "warning") {
+			t.Errorf("severity %s: template created = %t", severity, created)
+		}
+	}
+}
+
+func TestProviderConfigure_InvalidExternalLinterSeverity(t *testing.T) {
+	_, diags := configureTestHarness(t, newMockInfobip(t), map[string]tftypes.Value{
+		"external_linter_severity": tfString("fatal"),
+	})
+	if !strings.Contains(diagnosticsString(diags), "Invalid external linter severity") {
+		t.Errorf("diagnostics = %s, want an invalid severity error", diagnosticsString(diags))
+	}
+}
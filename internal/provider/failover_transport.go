@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+)
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &failoverTransport{}
+
+// failoverTransport sends requests that fail against the primary host once
+// more to a fallback host. Requests that never reached the primary host fail
+// over whatever their method; after a 5xx response or a connection error
+// mid-request, only idempotent requests do, since the primary host may
+// already have created a template or sent an email.
+//
+// The fallback request is part of the same attempt: any retry logic wrapping
+// this transport sees a single round trip whose result is the fallback's.
+type failoverTransport struct {
+	next           http.RoundTripper
+	fallbackScheme string
+	fallbackHost   string
+}
+
+func newFailoverTransport(next http.RoundTripper, fallbackScheme, fallbackHost string) *failoverTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &failoverTransport{
+		next:           next,
+		fallbackScheme: fallbackScheme,
+		fallbackHost:   fallbackHost,
+	}
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body is read by the first request, so keep a copy for the
+	// fallback when it cannot be recreated.
+	req, err := replayableRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if !shouldFailover(req, resp, err) {
+		return resp, err
+	}
+	if resp != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	fallback := req.Clone(req.Context())
+	fallback.URL.Scheme = t.fallbackScheme
+	fallback.URL.Host = t.fallbackHost
+	fallback.Host = ""
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+		fallback.Body = body
+	}
+
+	return t.next.RoundTrip(fallback)
+}
+
+// replayableRequest returns req with a GetBody that recreates its body, so
+// it can be sent more than once. A body that cannot be recreated is read
+// into memory.
+func replayableRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+
+	return req, nil
+}
+
+// shouldFailover reports whether the result of req against the primary host
+// warrants trying the fallback host. Cancelled requests are not retried.
+func shouldFailover(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil && isDialError(err) {
+		return true
+	}
+	if !isIdempotentMethod(req.Method) {
+		return false
+	}
+
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// isDialError reports whether err was raised while resolving or connecting
+// to the host, before any of the request was written.
+func isDialError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFailoverTransport_PrimaryUnreachable(t *testing.T) {
+	mock := newMockInfobip(t)
+
+	// A closed server leaves an address nothing listens on.
+	primary := httptest.NewTLSServer(http.NotFoundHandler())
+	primaryHost := strings.TrimPrefix(primary.URL, "https://")
+	primary.Close()
+
+	h := newTestHarness(t, mock, map[string]tftypes.Value{
+		"base_url":          tfString(primaryHost),
+		"base_url_fallback": tfString(mock.host()),
+	})
+	r := h.resource("pocinfobipemails_email_template")
+
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if got := len(mock.templates); got != 1 {
+		t.Errorf("templates on the fallback = %d, want 1", got)
+	}
+	if got := mock.lastForm()["name"]; got == "" {
+		t.Errorf("fallback received no form body")
+	}
+}
+
+func TestFailoverTransport(t *testing.T) {
+	cases := map[string]struct {
+		method        string
+		primaryStatus int
+		wantStatus    int
+		wantFallback  int
+	}{
+		"server error":         {method: http.MethodPut, primaryStatus: http.StatusServiceUnavailable, wantStatus: http.StatusOK, wantFallback: 1},
+		"server error on POST": {method: http.MethodPost, primaryStatus: http.StatusServiceUnavailable, wantStatus: http.StatusServiceUnavailable},
+		"client error":         {method: http.MethodPut, primaryStatus: http.StatusBadRequest, wantStatus: http.StatusBadRequest},
+		"success":              {method: http.MethodPut, primaryStatus: http.StatusOK, wantStatus: http.StatusOK},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			primaryCalls := 0
+			primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				primaryCalls++
+				w.WriteHeader(tc.primaryStatus)
+			}))
+			defer primary.Close()
+
+			var fallbackBodies []string
+			fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				fallbackBodies = append(fallbackBodies, string(body))
+			}))
+			defer fallback.Close()
+
+			client := &http.Client{Transport: newFailoverTransport(nil, "http", strings.TrimPrefix(fallback.URL, "http://"))}
+
+			// A reader without GetBody, so the transport must buffer it.
+			req, _ := http.NewRequest(tc.method, primary.URL+"/email/1/templates/1", io.NopCloser(strings.NewReader("name=welcome")))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("request: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if primaryCalls != 1 {
+				t.Errorf("primary calls = %d, want 1", primaryCalls)
+			}
+			if len(fallbackBodies) != tc.wantFallback {
+				t.Fatalf("fallback calls = %d, want %d", len(fallbackBodies), tc.wantFallback)
+			}
+			for _, body := range fallbackBodies {
+				if body != "name=welcome" {
+					t.Errorf("fallback body = %q, want the original body", body)
+				}
+			}
+		})
+	}
+}
+
+func TestFailoverTransport_Cancelled(t *testing.T) {
+	fallbackCalls := 0
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackCalls++
+	}))
+	defer fallback.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := &http.Client{Transport: newFailoverTransport(nil, "http", strings.TrimPrefix(fallback.URL, "http://"))}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, fallback.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected the cancelled request to fail")
+	}
+	if fallbackCalls != 0 {
+		t.Errorf("fallback calls = %d, want 0", fallbackCalls)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fromOverride rewrites the sender addresses that pattern matches in full to
+// address, in which $1 and ${name} expand to the submatches of the pattern.
+type fromOverride struct {
+	pattern *regexp.Regexp
+	address string
+}
+
+// fromOverrides rewrite the configured from of email templates, such as to
+// send from a different address in each environment. They are tried in the
+// order of their patterns, and the first match wins.
+type fromOverrides []fromOverride
+
+// parseFromOverrides compiles the from_overrides provider attribute, a map
+// of patterns to addresses.
+func parseFromOverrides(overrides map[string]string) (fromOverrides, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var parsed fromOverrides
+	for _, pattern := range sortedKeys(overrides) {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("from_overrides").AtMapKey(pattern),
+				"Invalid from override",
+				fmt.Sprintf("from_overrides key %q is not a valid regular expression: %s", pattern, err),
+			)
+			continue
+		}
+		parsed = append(parsed, fromOverride{pattern: re, address: overrides[pattern]})
+	}
+
+	return parsed, diags
+}
+
+// apply returns the from that is sent for the configured from: the address
+// of the first override matching it, or from itself. Unknown and null
+// values are returned as is.
+func (o fromOverrides) apply(from types.String) types.String {
+	if from.IsUnknown() || from.IsNull() {
+		return from
+	}
+
+	for _, override := range o {
+		if override.pattern.MatchString(from.ValueString()) {
+			return types.StringValue(override.pattern.ReplaceAllString(from.ValueString(), override.address))
+		}
+	}
+
+	return from
+}
+
+// stored returns the from to store for a template Infobip reports sending
+// from live: the configured from when it is rewritten to live, so an
+// override is not reported as drift, and live otherwise.
+func (o fromOverrides) stored(configured types.String, live string) types.String {
+	if !configured.IsNull() && !configured.IsUnknown() && o.apply(configured).ValueString() == live {
+		return configured
+	}
+
+	return types.StringValue(live)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_FromOverrides(t *testing.T) {
+	overrides := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		`(.*)<sender@example\.com>`: tfString("${1}<sender@dev.example.com>"),
+		`.*@other\.example\.com>`:   tfString("Other <other@dev.example.com>"),
+	})
+
+	cases := map[string]struct {
+		from          string
+		wantEffective string
+	}{
+		"matching override": {
+			from:          "Sender <sender@example.com>",
+			wantEffective: "Sender <sender@dev.example.com>",
+		},
+		"no matching override": {
+			from:          "Sender <sender@example.org>",
+			wantEffective: "Sender <sender@example.org>",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, map[string]tftypes.Value{"from_overrides": overrides})
+			r := h.resource("pocinfobipemails_email_template")
+
+			config := testEmailTemplateConfig(map[string]tftypes.Value{"from": tfString(tc.from)})
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+
+			var planned map[string]tftypes.Value
+			_ = r.planned.As(&planned)
+			if got := planned["effective_from"]; !got.Equal(tfString(tc.wantEffective)) {
+				t.Errorf("planned effective_from = %s, want %q", got, tc.wantEffective)
+			}
+			if got := mock.templates[1].From; got != tc.wantEffective {
+				t.Errorf("sent from = %q, want %q", got, tc.wantEffective)
+			}
+			if got := r.stringAttr("from"); got != tc.from {
+				t.Errorf("from = %q, want the configured %q", got, tc.from)
+			}
+			if got := r.stringAttr("effective_from"); got != tc.wantEffective {
+				t.Errorf("effective_from = %q, want %q", got, tc.wantEffective)
+			}
+
+			// The override is not drift, so a refreshed plan changes nothing.
+			if diags := r.refresh(); hasError(diags) {
+				t.Fatalf("refresh: %s", diagnosticsString(diags))
+			}
+			if got := r.stringAttr("from"); got != tc.from {
+				t.Errorf("refreshed from = %q, want the configured %q", got, tc.from)
+			}
+			if diags := r.apply(config); hasError(diags) {
+				t.Fatalf("apply: %s", diagnosticsString(diags))
+			}
+			if got := countRequests(mock, "PUT /email/1/templates/1"); got != 0 {
+				t.Errorf("updates = %d, want 0", got)
+			}
+		})
+	}
+}
+
+func TestProvider_InvalidFromOverride(t *testing.T) {
+	overrides := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"(.*@example.com": tfString("sender@dev.example.com"),
+	})
+
+	_, diags := configureTestHarness(t, newMockInfobip(t), map[string]tftypes.Value{"from_overrides": overrides})
+	if !strings.Contains(diagnosticsString(diags), "Invalid from override") {
+		t.Errorf("diagnostics = %s, want an invalid from override error", diagnosticsString(diags))
+	}
+}
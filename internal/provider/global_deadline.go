@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// apiDeadline is the wall-clock time after which the provider stops making
+// Infobip API calls, for CI jobs with a total time budget. A nil deadline
+// never passes.
+type apiDeadline struct {
+	// budget is the global_deadline the deadline was set from.
+	budget time.Duration
+	at     time.Time
+}
+
+// newAPIDeadline returns the deadline of a budget starting now.
+func newAPIDeadline(budget time.Duration) *apiDeadline {
+	return &apiDeadline{budget: budget, at: time.Now().Add(budget)}
+}
+
+// check reports an error once the deadline has passed, before the API call
+// described by operation, such as "create the email template".
+func (d *apiDeadline) check(operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d == nil || time.Now().Before(d.at) {
+		return diags
+	}
+
+	diags.AddError(
+		"Global API Deadline Exceeded",
+		fmt.Sprintf("Could not %s: the global API deadline exceeded at %s, %s after the provider was configured, "+
+			"so no further Infobip API calls are made. Increase global_deadline or run again.", operation, d.at.Format(time.RFC3339), d.budget),
+	)
+
+	return diags
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_GlobalDeadline(t *testing.T) {
+	mock := newMockInfobip(t)
+
+	// Within the deadline, calls are made.
+	h := newTestHarness(t, mock, map[string]tftypes.Value{"global_deadline": tfString("1h")})
+	r := h.resource("pocinfobipemails_email_template")
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	// Past the deadline, every method fails before calling the API.
+	expired := newTestHarness(t, mock, map[string]tftypes.Value{"global_deadline": tfString("1ms")})
+	time.Sleep(5 * time.Millisecond)
+	mock.mu.Lock()
+	calls := len(mock.requests)
+	mock.mu.Unlock()
+
+	late := expired.resource("pocinfobipemails_email_template")
+	late.state = r.state
+	steps := []struct {
+		name string
+		run  func() []*tfprotov6.Diagnostic
+	}{
+		{"read", late.refresh},
+		{"update", func() []*tfprotov6.Diagnostic {
+			return late.apply(testEmailTemplateConfig(map[string]tftypes.Value{"subject": tfString("Later")}))
+		}},
+		{"delete", late.destroy},
+		{"create", func() []*tfprotov6.Diagnostic {
+			return expired.resource("pocinfobipemails_email_template").apply(testEmailTemplateConfig(nil))
+		}},
+	}
+	for _, step := range steps {
+		if diags := step.run(); !strings.Contains(diagnosticsString(diags), "Global API Deadline Exceeded") {
+			t.Errorf("%s: diagnostics = %s, want Global API Deadline Exceeded", step.name, diagnosticsString(diags))
+		}
+	}
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	if len(mock.requests) != calls {
+		t.Errorf("requests after the deadline = %v, want none", mock.requests[calls:])
+	}
+}
+
+func TestGlobalDeadline_Invalid(t *testing.T) {
+	for _, value := range []string{"soon", "0s", "-5m"} {
+		_, diags := configureTestHarness(t, newMockInfobip(t), map[string]tftypes.Value{"global_deadline": tfString(value)})
+		if !strings.Contains(diagnosticsString(diags), "Invalid global deadline") {
+			t.Errorf("global_deadline %q: diagnostics = %s, want Invalid global deadline", value, diagnosticsString(diags))
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseHTMLChecksum returns the lower-cased hex digest of an html_checksum,
+// which is a hex sha256 digest optionally prefixed with "sha256:".
+func parseHTMLChecksum(raw string) (string, error) {
+	digest := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(raw), "sha256:"))
+	if len(digest) != hex.EncodedLen(sha256.Size) {
+		return "", fmt.Errorf("%q is not a sha256 digest: it must be %d hex characters", raw, hex.EncodedLen(sha256.Size))
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return "", fmt.Errorf("%q is not a sha256 digest: %w", raw, err)
+	}
+
+	return digest, nil
+}
+
+// checkHTMLChecksum fails when checksum is set and is not the sha256 digest
+// of the configured html. The configured html is hashed as written, since the
+// planned html may be the stored one when they only differ by whitespace.
+func checkHTMLChecksum(ctx context.Context, config tfsdk.Config, checksum types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if checksum.IsNull() || checksum.IsUnknown() {
+		return diags
+	}
+
+	want, err := parseHTMLChecksum(checksum.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("html_checksum"), "Invalid HTML Checksum", err.Error())
+		return diags
+	}
+
+	var html types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("html"), &html)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	sum := sha256.Sum256([]byte(html.ValueString()))
+	if got := hex.EncodeToString(sum[:]); got != want {
+		diags.AddAttributeError(
+			path.Root("html_checksum"),
+			"HTML Checksum Mismatch",
+			fmt.Sprintf("The sha256 digest of html is %s, but html_checksum is %s. "+
+				"Check that the html is loaded from the expected file, or update html_checksum.", got, want),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_HTMLChecksum(t *testing.T) {
+	html := "<html><body><h1>Hi</h1></body></html>\n"
+	digest := sha256Hex(html)
+
+	cases := map[string]struct {
+		checksum string
+		match    bool
+	}{
+		"matching":        {checksum: digest, match: true},
+		"prefixed":        {checksum: "sha256:" + strings.ToUpper(digest), match: true},
+		"mismatching":     {checksum: strings.Repeat("0", 64)},
+		"normalized html": {checksum: sha256Hex("<html><body><h1>Hi</h1></body></html>")},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, nil)
+			r := h.resource("pocinfobipemails_email_template")
+
+			diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{
+				"html":          tfString(html),
+				"html_checksum": tfString(tc.checksum),
+			}))
+			if tc.match && hasError(diags) {
+				t.Fatalf("apply: %s", diagnosticsString(diags))
+			}
+			if !tc.match {
+				if !strings.Contains(diagnosticsString(diags), "HTML Checksum Mismatch") {
+					t.Fatalf("diagnostics = %s, want HTML Checksum Mismatch", diagnosticsString(diags))
+				}
+				if len(mock.templates) != 0 {
+					t.Errorf("templates = %d, want none created", len(mock.templates))
+				}
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_HTMLChecksumOnUpdate(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	// The checksum of the previous html no longer matches the new html.
+	previous := sha256Hex(r.stringAttr("html"))
+	diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{
+		"html":          tfString("<html><body><h1>Hello</h1></body></html>"),
+		"html_checksum": tfString(previous),
+	}))
+	if !strings.Contains(diagnosticsString(diags), "HTML Checksum Mismatch") {
+		t.Fatalf("diagnostics = %s, want HTML Checksum Mismatch", diagnosticsString(diags))
+	}
+}
+
+func TestEmailTemplateResource_InvalidHTMLChecksum(t *testing.T) {
+	h := newTestHarness(t, newMockInfobip(t), nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	for _, checksum := range []string{"abc", "md5:" + strings.Repeat("0", 64), strings.Repeat("z", 64)} {
+		diags := r.validate(testEmailTemplateConfig(map[string]tftypes.Value{"html_checksum": tfString(checksum)}))
+		if !strings.Contains(diagnosticsString(diags), "Invalid HTML Checksum") {
+			t.Errorf("html_checksum %q: diagnostics = %s, want Invalid HTML Checksum", checksum, diagnosticsString(diags))
+		}
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
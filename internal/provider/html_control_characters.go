@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure interface compliance.
+var _ validator.String = htmlControlCharactersValidator{}
+
+// htmlControlCharactersValidator rejects html containing control characters
+// other than tab, newline and carriage return, such as a stray NUL or
+// vertical tab, which can break the API or rendering. It checks the html as
+// configured, before it is normalized.
+type htmlControlCharactersValidator struct{}
+
+func (v htmlControlCharactersValidator) Description(ctx context.Context) string {
+	return "Value must not contain control characters other than tab, newline and carriage return."
+}
+
+func (v htmlControlCharactersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v htmlControlCharactersValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if offset, c, ok := findControlCharacter(req.ConfigValue.ValueString()); ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Control Character in HTML",
+			fmt.Sprintf("The html contains the control character %U at byte offset %d. "+
+				"Remove it; only tab, newline and carriage return are allowed.", c, offset),
+		)
+	}
+}
+
+// findControlCharacter returns the byte offset of the first control character
+// in s other than tab, newline and carriage return.
+func findControlCharacter(s string) (int, rune, bool) {
+	for i, c := range s {
+		switch c {
+		case '\t', '\n', '\r':
+			continue
+		}
+		if unicode.IsControl(c) {
+			return i, c, true
+		}
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// privateKeyHTMLSource is the private state key holding the normalized html
+// as configured, before remote images were inlined, so that plans compare
+// the configuration against it instead of the inlined html.
+const privateKeyHTMLSource = "html_source"
+
+// inlineImageMaxBytes caps the size of a single inlined image. Larger images
+// keep their URL, since they would push the html towards the size at which
+// Gmail clips messages.
+const inlineImageMaxBytes = 64 << 10
+
+// inlineImageTimeout bounds the time spent fetching a single image.
+const inlineImageTimeout = 10 * time.Second
+
+// remoteImagePattern matches the src attribute of img tags that point to an
+// http(s) URL. The groups are the text before the URL, the URL and the
+// closing quote.
+var remoteImagePattern = regexp.MustCompile(`(?i)(<img\b[^>]*?\ssrc\s*=\s*["'])(https?://[^"'\s>]+)(["'])`)
+
+// inlineRemoteImages replaces the http(s) src of every img tag in raw with a
+// data: URI of the fetched image. Images that cannot be fetched, are not
+// images or are larger than inlineImageMaxBytes keep their URL, and a warning
+// is returned for each of them.
+func inlineRemoteImages(ctx context.Context, client *http.Client, raw string) (string, []string) {
+	var warnings []string
+	dataURIs := map[string]string{}
+
+	for _, match := range remoteImagePattern.FindAllStringSubmatch(raw, -1) {
+		url := match[2]
+		if _, ok := dataURIs[url]; ok {
+			continue
+		}
+
+		dataURI, err := fetchImageDataURI(ctx, client, url)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("image %s was not inlined: %s", url, err))
+		}
+		dataURIs[url] = dataURI
+	}
+
+	html := remoteImagePattern.ReplaceAllStringFunc(raw, func(tag string) string {
+		match := remoteImagePattern.FindStringSubmatch(tag)
+		if dataURIs[match[2]] == "" {
+			return tag
+		}
+
+		return match[1] + dataURIs[match[2]] + match[3]
+	})
+
+	return html, warnings
+}
+
+// fetchImageDataURI fetches the image at url and returns it as a base64
+// data: URI.
+func fetchImageDataURI(ctx context.Context, client *http.Client, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, inlineImageTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return "", fmt.Errorf("content type %q is not an image", resp.Header.Get("Content-Type"))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, inlineImageMaxBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > inlineImageMaxBytes {
+		return "", fmt.Errorf("image is larger than %d bytes", inlineImageMaxBytes)
+	}
+
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(body), nil
+}
+
+// htmlSourceValue returns the private state value for privateKeyHTMLSource.
+func htmlSourceValue(html string) []byte {
+	value, _ := json.Marshal(normalizeHTML(html))
+
+	return value
+}
+
+// htmlSourceFromPrivate decodes a privateKeyHTMLSource value. It reports
+// false when the value is missing.
+func htmlSourceFromPrivate(value []byte) (string, bool) {
+	var html string
+	if len(value) == 0 || json.Unmarshal(value, &html) != nil {
+		return "", false
+	}
+
+	return html, true
+}
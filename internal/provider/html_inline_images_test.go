@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// smallPNG is a 1x1 transparent PNG.
+var smallPNG, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII=")
+
+// newImageServer serves /small.png, /big.png (over inlineImageMaxBytes) and
+// /page.html, and counts the requests it receives.
+func newImageServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/small.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(smallPNG)
+		case "/big.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(make([]byte, inlineImageMaxBytes+1))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<p>not an image</p>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestInlineRemoteImages(t *testing.T) {
+	server, requests := newImageServer(t)
+	small := server.URL + "/small.png"
+
+	raw := `<p><img src="` + small + `" alt="logo"><IMG width="1" SRC='` + small + `'>` +
+		`<img src="` + server.URL + `/big.png"><img src="` + server.URL + `/missing.png">` +
+		`<img src="` + server.URL + `/page.html"><a href="` + small + `">link</a></p>`
+
+	html, warnings := inlineRemoteImages(context.Background(), http.DefaultClient, raw)
+
+	dataURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString(smallPNG)
+	if got := strings.Count(html, dataURI); got != 2 {
+		t.Errorf("inlined the small image %d times, want 2: %s", got, html)
+	}
+	for _, kept := range []string{`src="` + server.URL + `/big.png"`, `/missing.png"`, `/page.html"`, `href="` + small + `"`} {
+		if !strings.Contains(html, kept) {
+			t.Errorf("html does not keep %s: %s", kept, html)
+		}
+	}
+
+	if len(warnings) != 3 {
+		t.Fatalf("warnings = %q, want one each for the big, missing and non-image URLs", warnings)
+	}
+	for i, want := range []string{"larger than", "404", "not an image"} {
+		if !strings.Contains(warnings[i], want) {
+			t.Errorf("warning %d = %q, want it to contain %q", i, warnings[i], want)
+		}
+	}
+
+	// The repeated URL is fetched once.
+	if got := requests.Load(); got != 4 {
+		t.Errorf("image requests = %d, want 4", got)
+	}
+}
+
+func TestEmailTemplateResource_InlineRemoteImages(t *testing.T) {
+	server, requests := newImageServer(t)
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, map[string]tftypes.Value{"inline_remote_images": tfBool(true)})
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"html": tfString(`<html><body><img src="` + server.URL + `/small.png"><img src="` + server.URL + `/big.png"></body></html>`),
+	})
+	diags := r.apply(config)
+	if hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if !strings.Contains(diagnosticsString(diags), "Remote Image Not Inlined") {
+		t.Errorf("expected a warning for the oversized image: %s", diagnosticsString(diags))
+	}
+
+	sent := mock.lastForm()["html"]
+	if !strings.Contains(sent, "data:image/png;base64,") || !strings.Contains(sent, server.URL+"/big.png") {
+		t.Fatalf("sent html = %q, want the small image inlined and the big one kept", sent)
+	}
+	if got := r.stringAttr("html_raw"); got != sent {
+		t.Errorf("html_raw = %q, want the html as sent", got)
+	}
+
+	// The configuration no longer matches the stored html, but plans no
+	// change and fetches nothing, also after an update of another field.
+	fetched := requests.Load()
+	for _, subject := range []string{"Welcome aboard", "Changed subject"} {
+		prior := map[string]string{"html": r.stringAttr("html"), "html_raw": r.stringAttr("html_raw")}
+		config["subject"] = tfString(subject)
+		if diags := r.apply(config); hasError(diags) {
+			t.Fatalf("re-apply: %s", diagnosticsString(diags))
+		}
+
+		var planned map[string]tftypes.Value
+		_ = r.planned.As(&planned)
+		for name, value := range prior {
+			if !planned[name].Equal(tfString(value)) {
+				t.Errorf("subject %q: planned %s = %s, want prior value", subject, name, planned[name])
+			}
+		}
+	}
+	if got := requests.Load(); got != fetched {
+		t.Errorf("images were fetched %d more times for unchanged html", got-fetched)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// htmlClipBytes is the size above which Gmail clips a message body.
+const htmlClipBytes = 102 * 1024
+
+// htmlVoidElements never have a closing tag.
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlOptionalEndTags may be left open, since HTML closes them implicitly.
+var htmlOptionalEndTags = map[string]bool{
+	"html": true, "head": true, "body": true, "p": true, "li": true,
+	"dt": true, "dd": true, "tr": true, "td": true, "th": true,
+	"thead": true, "tbody": true, "tfoot": true, "caption": true,
+	"colgroup": true, "option": true, "optgroup": true, "rb": true,
+	"rt": true, "rtc": true, "rp": true,
+}
+
+var unsubscribeMarker = regexp.MustCompile(`(?i)unsubscribe`)
+
+// htmlLintChecks are run in order by lintHTML.
+var htmlLintChecks = []func(raw string) []string{
+	lintUnclosedTags,
+	lintMissingUnsubscribe,
+	lintLocalLinks,
+	lintOversizedBody,
+}
+
+// lintHTML returns a human readable warning for every problem found in an
+// email html body. An empty result means the html passed every check.
+func lintHTML(raw string) []string {
+	warnings := []string{}
+	for _, check := range htmlLintChecks {
+		warnings = append(warnings, check(raw)...)
+	}
+
+	return warnings
+}
+
+// lintUnclosedTags reports elements that are opened but never closed, and
+// closing tags without a matching opening tag. Void elements and elements
+// whose end tag is optional may be left open.
+func lintUnclosedTags(raw string) []string {
+	var warnings []string
+	var open []string
+
+	z := html.NewTokenizer(strings.NewReader(raw))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				warnings = append(warnings, fmt.Sprintf("html could not be parsed: %s", z.Err()))
+			}
+			break
+		}
+
+		name, _ := z.TagName()
+		tag := string(name)
+		switch tt {
+		case html.StartTagToken:
+			if !htmlVoidElements[tag] {
+				open = append(open, tag)
+			}
+		case html.EndTagToken:
+			i := len(open) - 1
+			for i >= 0 && open[i] != tag {
+				i--
+			}
+			if i < 0 {
+				warnings = append(warnings, fmt.Sprintf("closing tag </%s> has no matching opening tag", tag))
+				continue
+			}
+			warnings = append(warnings, unclosedTagWarnings(open[i+1:])...)
+			open = open[:i]
+		}
+	}
+
+	return append(warnings, unclosedTagWarnings(open)...)
+}
+
+// unclosedTagWarnings returns a warning for each tag in open that must be
+// closed explicitly.
+func unclosedTagWarnings(open []string) []string {
+	var warnings []string
+	for _, tag := range open {
+		if !htmlOptionalEndTags[tag] {
+			warnings = append(warnings, fmt.Sprintf("tag <%s> is not closed", tag))
+		}
+	}
+
+	return warnings
+}
+
+// lintMissingUnsubscribe reports bodies without an unsubscribe link or
+// placeholder, which most mailbox providers expect in bulk email.
+func lintMissingUnsubscribe(raw string) []string {
+	if unsubscribeMarker.MatchString(raw) {
+		return nil
+	}
+
+	return []string{"html has no unsubscribe link"}
+}
+
+// lintLocalLinks reports href and src attributes pointing at local hosts,
+// which recipients cannot reach.
+func lintLocalLinks(raw string) []string {
+	var warnings []string
+
+	z := html.NewTokenizer(strings.NewReader(raw))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		for {
+			key, val, more := z.TagAttr()
+			if attr := string(key); attr == "href" || attr == "src" {
+				if u, err := url.Parse(strings.TrimSpace(string(val))); err == nil && isLocalHost(u.Hostname()) {
+					warnings = append(warnings, fmt.Sprintf("%s %q points to a local host", attr, string(val)))
+				}
+			}
+			if !more {
+				break
+			}
+		}
+	}
+
+	return warnings
+}
+
+func isLocalHost(host string) bool {
+	host = strings.ToLower(host)
+
+	return host == "localhost" || strings.HasSuffix(host, ".localhost") ||
+		strings.HasPrefix(host, "127.") || host == "0.0.0.0" || host == "::1"
+}
+
+// lintOversizedBody reports bodies large enough to be clipped by Gmail.
+func lintOversizedBody(raw string) []string {
+	if len(raw) <= htmlClipBytes {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("html is %d bytes; bodies over %d bytes are clipped by Gmail", len(raw), htmlClipBytes)}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/tdewolff/minify/v2"
+	"github.com/tdewolff/minify/v2/html"
+)
+
+// htmlMinifier only removes what email clients ignore. Conditional comments
+// (used to target Outlook), document and end tags, quotes and default
+// attribute values are kept, as are Infobip {{placeholders}}. The content
+// of <pre> and <textarea> is never changed.
+var htmlMinifier = func() *minify.M {
+	m := minify.New()
+	m.Add("text/html", &html.Minifier{
+		KeepDefaultAttrVals: true,
+		KeepDocumentTags:    true,
+		KeepEndTags:         true,
+		KeepQuotes:          true,
+		KeepSpecialComments: true,
+		TemplateDelims:      [2]string{"{{", "}}"},
+	})
+
+	return m
+}()
+
+// minifyHTML returns the minified form of raw. Minifying is idempotent, so
+// the result minifies to itself.
+func minifyHTML(raw string) (string, error) {
+	return htmlMinifier.String("text/html", raw)
+}
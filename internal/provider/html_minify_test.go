@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMinifyHTML(t *testing.T) {
+	cases := map[string]struct {
+		html      string
+		preserved []string
+	}{
+		"whitespace": {
+			html: "<html>\n  <body>\n    <p>Hello   there</p>\n  </body>\n</html>\n",
+		},
+		"pre and textarea": {
+			html:      "<body><pre>  keep\n    this  </pre><textarea>  and\n  this </textarea></body>",
+			preserved: []string{"<pre>  keep\n    this  </pre>", "<textarea>  and\n  this </textarea>"},
+		},
+		"conditional comments": {
+			html:      "<body><!--[if mso]><table><tr><td><![endif]--><p>x</p><!-- dropped --></body>",
+			preserved: []string{"<!--[if mso]>", "<![endif]-->"},
+		},
+		"placeholders": {
+			html:      `<body><p>Hi {{ first_name }}</p><a href="{{unsubscribe_url}}">Unsubscribe</a></body>`,
+			preserved: []string{"{{ first_name }}", `href="{{unsubscribe_url}}"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			once, err := minifyHTML(tc.html)
+			if err != nil {
+				t.Fatalf("minify: %s", err)
+			}
+			twice, err := minifyHTML(once)
+			if err != nil {
+				t.Fatalf("minify again: %s", err)
+			}
+
+			if once != twice {
+				t.Errorf("minification is not idempotent:\n once: %q\ntwice: %q", once, twice)
+			}
+			if len(once) > len(tc.html) {
+				t.Errorf("minified html is longer than the input: %q", once)
+			}
+			for _, s := range tc.preserved {
+				if !strings.Contains(once, s) {
+					t.Errorf("minified html %q does not contain %q", once, s)
+				}
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_MinifyHTML(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, map[string]tftypes.Value{"minify_html": tfBool(true)})
+	r := h.resource("pocinfobipemails_email_template")
+
+	raw := "<html>\n  <body>\n    <!-- build 42 -->\n    <p>Hello</p>\n  </body>\n</html>\n"
+	config := testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString(raw)})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	want, _ := minifyHTML(raw)
+	if got := mock.lastForm()["html"]; got != want {
+		t.Fatalf("sent html = %q, want %q", got, want)
+	}
+	if got := r.stringAttr("html_raw"); got != want {
+		t.Fatalf("html_raw = %q, want the html as sent %q", got, want)
+	}
+
+	// The same configuration, which differs from the stored html by more
+	// than whitespace, plans no change.
+	prior := map[string]string{"html": r.stringAttr("html"), "html_raw": r.stringAttr("html_raw")}
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("re-apply: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	for name, value := range prior {
+		if !planned[name].Equal(tfString(value)) {
+			t.Errorf("planned %s = %s, want prior value %q", name, planned[name], value)
+		}
+	}
+}
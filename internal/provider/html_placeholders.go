@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// placeholderPattern matches Infobip merge placeholders such as
+// "{{first_name}}" or "{{ company.name }}". A leading backslash escapes the
+// placeholder. Only the innermost braces of nested placeholders match.
+var placeholderPattern = regexp.MustCompile(`(\\?)\{\{\s*([A-Za-z_][A-Za-z0-9_.\-]*)\s*\}\}`)
+
+// defaultPlaceholderSyntax is the placeholder_syntax of Infobip's native
+// placeholders.
+const defaultPlaceholderSyntax = "double_braces"
+
+// placeholderSyntaxes maps every placeholder_syntax to the pattern of its
+// placeholders. Like placeholderPattern, each pattern captures an escaping
+// backslash and the placeholder name.
+var placeholderSyntaxes = map[string]*regexp.Regexp{
+	defaultPlaceholderSyntax: placeholderPattern,
+	// "%first_name%"
+	"percent": regexp.MustCompile(`(\\?)%([A-Za-z_][A-Za-z0-9_.\-]*)%`),
+	// "${first_name}" or "${ company.name }"
+	"dollar_braces": regexp.MustCompile(`(\\?)\$\{\s*([A-Za-z_][A-Za-z0-9_.\-]*)\s*\}`),
+}
+
+// htmlPlaceholders returns the sorted, deduplicated names of the merge
+// placeholders in html matched by pattern, or of Infobip placeholders when
+// pattern is nil.
+func htmlPlaceholders(html string, pattern *regexp.Regexp) []string {
+	if pattern == nil {
+		pattern = placeholderPattern
+	}
+
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, match := range pattern.FindAllStringSubmatch(html, -1) {
+		if match[1] != "" || seen[match[2]] {
+			continue
+		}
+
+		seen[match[2]] = true
+		names = append(names, match[2])
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// placeholdersValue returns the placeholders attribute value for html, with
+// placeholders matched by pattern as in htmlPlaceholders.
+func placeholdersValue(html string, pattern *regexp.Regexp) types.Set {
+	elements := []attr.Value{}
+	for _, name := range htmlPlaceholders(html, pattern) {
+		elements = append(elements, types.StringValue(name))
+	}
+
+	return types.SetValueMust(types.StringType, elements)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestHTMLPlaceholders(t *testing.T) {
+	cases := map[string]struct {
+		html string
+		want []string
+	}{
+		"none": {
+			html: "<p>Hello</p>",
+			want: []string{},
+		},
+		"several, sorted and deduplicated": {
+			html: `<p>Hi {{first_name}} {{ last_name }},</p><p>{{company.name}}</p><a href="{{unsubscribe-url}}">{{first_name}}</a>`,
+			want: []string{"company.name", "first_name", "last_name", "unsubscribe-url"},
+		},
+		"escaped": {
+			html: `<p>Write \{{literal}} to show braces, {{shown}}</p>`,
+			want: []string{"shown"},
+		},
+		"nested": {
+			html: `<p>{{outer {{inner}} }}</p><p>{{{triple}}}</p>`,
+			want: []string{"inner", "triple"},
+		},
+		"not placeholders": {
+			html: `<style>a{color:red}</style><p>{{}} {{ 1st }} {{two words}} {single}</p>`,
+			want: []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := htmlPlaceholders(tc.html, nil); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("htmlPlaceholders = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHTMLPlaceholders_Syntaxes(t *testing.T) {
+	const html = `<p>Hi {{braces}} %percent% ${dollar} ${ spaced.name }, 100% off 50%</p>` +
+		`<p>\%escaped% \${escaped} %first_name%</p>`
+
+	cases := map[string][]string{
+		"double_braces": {"braces"},
+		"percent":       {"first_name", "percent"},
+		"dollar_braces": {"dollar", "spaced.name"},
+	}
+	for syntax, want := range cases {
+		t.Run(syntax, func(t *testing.T) {
+			if got := htmlPlaceholders(html, placeholderSyntaxes[syntax]); !reflect.DeepEqual(got, want) {
+				t.Errorf("htmlPlaceholders = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_PlaceholderSyntax(t *testing.T) {
+	html := "<p>Hi %first_name%, your code is %code%. {{not_detected}}</p>"
+
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, map[string]tftypes.Value{"placeholder_syntax": tfString("percent")})
+	r := h.resource("pocinfobipemails_email_template")
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString(html)})); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	want := tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tfString("code"), tfString("first_name")})
+	if got := r.attr("placeholders"); !got.Equal(want) {
+		t.Errorf("placeholders = %s, want [code first_name]", got)
+	}
+}
+
+func TestProvider_InvalidPlaceholderSyntax(t *testing.T) {
+	_, diags := configureTestHarness(t, newMockInfobip(t), map[string]tftypes.Value{"placeholder_syntax": tfString("angle_brackets")})
+	if !strings.Contains(diagnosticsString(diags), "Invalid placeholder syntax") {
+		t.Errorf("diagnostics = %s, want an invalid placeholder syntax error", diagnosticsString(diags))
+	}
+}
+
+func TestEmailTemplateResource_Placeholders(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"html": tfString("<p>Hi {{ first_name }}, your code is {{code}}.</p>\n<p>{{first_name}}</p>"),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	placeholders := func(v tftypes.Value) []string {
+		var items []tftypes.Value
+		_ = v.As(&items)
+		names := []string{}
+		for _, item := range items {
+			var s string
+			_ = item.As(&s)
+			names = append(names, s)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	want := []string{"code", "first_name"}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	if got := placeholders(planned["placeholders"]); !reflect.DeepEqual(got, want) {
+		t.Errorf("planned placeholders = %q, want %q", got, want)
+	}
+	if got := placeholders(r.attr("placeholders")); !reflect.DeepEqual(got, want) {
+		t.Errorf("placeholders = %q, want %q", got, want)
+	}
+
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	if got := placeholders(r.attr("placeholders")); !reflect.DeepEqual(got, want) {
+		t.Errorf("placeholders after refresh = %q, want %q", got, want)
+	}
+}
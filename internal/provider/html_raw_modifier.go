@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure interface compliance.
+var _ planmodifier.String = htmlRawModifier{}
+
+// htmlRawModifier plans html_raw as the configured html, keeping the prior
+// value when the html only differs by whitespace so it never causes a diff
+// on its own.
+type htmlRawModifier struct{}
+
+func (m htmlRawModifier) Description(ctx context.Context) string {
+	return "Plans the configured html verbatim unless it only differs from state by whitespace."
+}
+
+func (m htmlRawModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m htmlRawModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var configHtml types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("html"), &configHtml)...)
+	if resp.Diagnostics.HasError() || configHtml.IsUnknown() || configHtml.IsNull() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() && !req.StateValue.IsNull() {
+		var stateHtml types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("html"), &stateHtml)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if normalizeHTML(stateHtml.ValueString()) == normalizeHTML(configHtml.ValueString()) {
+			resp.PlanValue = req.StateValue
+			return
+		}
+	}
+
+	resp.PlanValue = configHtml
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// defaultSlotName is the slot filled by child html without slot blocks.
+const defaultSlotName = "content"
+
+// slotPattern matches a slot block such as
+// "<!-- slot:header -->content<!-- /slot:header -->". The groups are the
+// opening name, the content and the closing name; Go regexps have no
+// backreferences, so blocks whose names differ are skipped by the callers.
+var slotPattern = regexp.MustCompile(`(?s)<!--\s*slot:([A-Za-z0-9_-]+)\s*-->(.*?)<!--\s*/slot:([A-Za-z0-9_-]+)\s*-->`)
+
+// htmlSlots returns the content of every slot block in raw, by slot name.
+func htmlSlots(raw string) map[string]string {
+	slots := map[string]string{}
+	for _, match := range slotPattern.FindAllStringSubmatch(raw, -1) {
+		if match[1] == match[3] {
+			slots[match[1]] = match[2]
+		}
+	}
+
+	return slots
+}
+
+// composeHTML fills the slots of the parent html with the slot blocks of the
+// child html. Slots the child does not fill keep the parent's content, and a
+// child without slot blocks fills the "content" slot. The slot markers are
+// kept, so the result can be the parent of another template. Filling a slot
+// the parent does not define is an error.
+func composeHTML(parent, child string) (string, error) {
+	fills := htmlSlots(child)
+	if len(fills) == 0 {
+		fills = map[string]string{defaultSlotName: child}
+	}
+
+	defined := map[string]bool{}
+	composed := slotPattern.ReplaceAllStringFunc(parent, func(block string) string {
+		match := slotPattern.FindStringSubmatch(block)
+		name := match[1]
+		if name != match[3] {
+			return block
+		}
+
+		defined[name] = true
+		content, ok := fills[name]
+		if !ok {
+			return block
+		}
+
+		return "<!-- slot:" + name + " -->" + content + "<!-- /slot:" + name + " -->"
+	})
+
+	var missing []string
+	for name := range fills {
+		if !defined[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf("the parent template has no slot named %s; slots are declared as <!-- slot:name --><!-- /slot:name -->",
+			strings.Join(missing, ", "))
+	}
+
+	return composed, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testParentHTML = `<html><body>` +
+	`<!-- slot:header --><h1>Acme</h1><!-- /slot:header -->` +
+	`<!-- slot:content --><!-- /slot:content -->` +
+	`<p>Unsubscribe</p></body></html>`
+
+func TestComposeHTML(t *testing.T) {
+	cases := map[string]struct {
+		child   string
+		want    string
+		wantErr string
+	}{
+		"default slot": {
+			child: `<p>Welcome!</p>`,
+			want: `<html><body>` +
+				`<!-- slot:header --><h1>Acme</h1><!-- /slot:header -->` +
+				`<!-- slot:content --><p>Welcome!</p><!-- /slot:content -->` +
+				`<p>Unsubscribe</p></body></html>`,
+		},
+		"named slots": {
+			child: `<!-- slot:header --><h1>Sale</h1><!-- /slot:header -->` +
+				"ignored\n" +
+				`<!--slot:content-->50% off<!--/slot:content-->`,
+			want: `<html><body>` +
+				`<!-- slot:header --><h1>Sale</h1><!-- /slot:header -->` +
+				`<!-- slot:content -->50% off<!-- /slot:content -->` +
+				`<p>Unsubscribe</p></body></html>`,
+		},
+		"unfilled slot keeps the parent content": {
+			child: `<!-- slot:content -->Hi<!-- /slot:content -->`,
+			want: `<html><body>` +
+				`<!-- slot:header --><h1>Acme</h1><!-- /slot:header -->` +
+				`<!-- slot:content -->Hi<!-- /slot:content -->` +
+				`<p>Unsubscribe</p></body></html>`,
+		},
+		"unknown slot": {
+			child:   `<!-- slot:footer -->Bye<!-- /slot:footer -->`,
+			wantErr: "no slot named footer",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := composeHTML(testParentHTML, tc.child)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("composeHTML() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("composeHTML() error = %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("composeHTML() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_ParentID(t *testing.T) {
+	mock := newMockInfobip(t)
+	parentID := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Layout", Subject: "Layout", HTML: testParentHTML})
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"parent_id": tfString(fmt.Sprintf("%d", parentID)),
+		"html":      tfString(`<p>Hello {{first_name}}</p>`),
+	})
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	wantHTML, _ := composeHTML(testParentHTML, `<p>Hello {{first_name}}</p>`)
+	if got := mock.lastForm()["html"]; got != wantHTML {
+		t.Errorf("sent html = %q, want %q", got, wantHTML)
+	}
+	if got := r.stringAttr("resolved_html"); got != wantHTML {
+		t.Errorf("resolved_html = %q, want %q", got, wantHTML)
+	}
+
+	// Applying the same configuration plans no html change.
+	var prior map[string]tftypes.Value
+	_ = r.state.As(&prior)
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("re-apply: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	for _, name := range []string{"html", "html_raw", "resolved_html"} {
+		if !planned[name].Equal(prior[name]) {
+			t.Errorf("re-apply planned %s = %s, want the prior %s", name, planned[name], prior[name])
+		}
+	}
+
+	// A change to the parent layout is applied to the child.
+	mock.templates[parentID].HTML = strings.Replace(testParentHTML, "Acme", "Acme Corp", 1)
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	if got := mock.lastForm()["html"]; !strings.Contains(got, "Acme Corp") || !strings.Contains(got, "Hello {{first_name}}") {
+		t.Errorf("sent html = %q, want the new layout with the child content", got)
+	}
+}
+
+func TestEmailTemplateResource_ParentIDUnknownSlot(t *testing.T) {
+	mock := newMockInfobip(t)
+	parentID := mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Layout", Subject: "Layout", HTML: testParentHTML})
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{
+		"parent_id": tfString(fmt.Sprintf("%d", parentID)),
+		"html":      tfString(`<!-- slot:footer -->Bye<!-- /slot:footer -->`),
+	}))
+	if !strings.Contains(diagnosticsString(diags), "Error Composing Email Template") {
+		t.Errorf("diagnostics = %s, want a composition error", diagnosticsString(diags))
+	}
+}
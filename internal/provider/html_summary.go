@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// htmlSummaryDigestLength is the number of hex characters of the sha256
+// digest shown in html_summary, enough to tell versions apart at a glance.
+const htmlSummaryDigestLength = 12
+
+// htmlSummary describes html by its line count, byte count and a shortened
+// sha256 digest, such as "1250 lines, 51234 bytes, sha256:9b21c0de4f3a", so
+// plans of large html show a readable change next to the full diff.
+func htmlSummary(html string) string {
+	lines := 0
+	if html != "" {
+		lines = strings.Count(strings.TrimSuffix(html, "\n"), "\n") + 1
+	}
+	sum := sha256.Sum256([]byte(html))
+
+	return fmt.Sprintf("%d lines, %d bytes, sha256:%s", lines, len(html), hex.EncodeToString(sum[:])[:htmlSummaryDigestLength])
+}
+
+// htmlSummaryValue returns the html_summary of html, unknown or null like
+// html itself.
+func htmlSummaryValue(html types.String) types.String {
+	if html.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if html.IsNull() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(htmlSummary(html.ValueString()))
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestHTMLSummary(t *testing.T) {
+	cases := map[string]struct {
+		html string
+		want string
+	}{
+		"empty":             {html: "", want: "0 lines, 0 bytes, sha256:e3b0c44298fc"},
+		"single line":       {html: "<p>Hi</p>", want: "1 lines, 9 bytes, sha256:"},
+		"trailing newline":  {html: "<p>Hi</p>\n<p>Bye</p>\n", want: "2 lines, 21 bytes, sha256:"},
+		"multibyte content": {html: "<p>é</p>", want: "1 lines, 9 bytes, sha256:"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := htmlSummary(tc.html)
+			if !strings.HasPrefix(got, tc.want) {
+				t.Errorf("htmlSummary(%q) = %q, want prefix %q", tc.html, got, tc.want)
+			}
+			if digest := got[strings.LastIndex(got, ":")+1:]; len(digest) != htmlSummaryDigestLength {
+				t.Errorf("digest %q has %d characters, want %d", digest, len(digest), htmlSummaryDigestLength)
+			}
+		})
+	}
+
+	if htmlSummary("<p>Hi</p>") == htmlSummary("<p>Ho</p>") {
+		t.Error("different html of the same size has the same summary")
+	}
+}
+
+func TestEmailTemplateResource_HTMLSummary(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(nil)
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	created := r.stringAttr("html_summary")
+	if created != htmlSummary(r.stringAttr("html")) {
+		t.Errorf("html_summary = %q, want the summary of %q", created, r.stringAttr("html"))
+	}
+
+	// A change is planned with the summary of the new html.
+	config["html"] = tfString("<html><body><h1>Hi</h1><p>A longer body</p></body></html>")
+	if diags := r.apply(config); hasError(diags) {
+		t.Fatalf("update: %s", diagnosticsString(diags))
+	}
+	var planned map[string]tftypes.Value
+	_ = r.planned.As(&planned)
+	want := htmlSummary("<html><body><h1>Hi</h1><p>A longer body</p></body></html>")
+	if !planned["html_summary"].Equal(tfString(want)) {
+		t.Errorf("planned html_summary = %s, want %q", planned["html_summary"], want)
+	}
+	if got := r.stringAttr("html_summary"); got != want || got == created {
+		t.Errorf("html_summary = %q, want %q", got, want)
+	}
+
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	if got := r.stringAttr("html_summary"); got != want {
+		t.Errorf("refreshed html_summary = %q, want %q", got, want)
+	}
+}
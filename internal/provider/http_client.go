@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+)
+
+const (
+	// defaultMaxIdleConns bounds the idle connections kept across all hosts.
+	defaultMaxIdleConns = 100
+	// defaultMaxIdleConnsPerHost keeps enough connections to the Infobip API
+	// for Terraform's default parallelism of 10. http.DefaultTransport only
+	// keeps 2, so parallel applies keep closing connections and paying for
+	// new TLS handshakes.
+	defaultMaxIdleConnsPerHost = 10
+)
+
+// newDefaultHTTPClient returns the client used for the Infobip API when none
+// is injected: http.DefaultTransport with connection reuse tuned for
+// parallel applies.
+func newDefaultHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = defaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewDefaultHTTPClient(t *testing.T) {
+	transport, ok := newDefaultHTTPClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport is not an *http.Transport")
+	}
+	if transport.MaxIdleConns != 100 {
+		t.Errorf("MaxIdleConns = %d, want 100", transport.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != 10 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 10", transport.MaxIdleConnsPerHost)
+	}
+	if transport.Proxy == nil {
+		t.Errorf("Proxy is nil, want the settings of http.DefaultTransport")
+	}
+}
+
+// TestNewDefaultHTTPClient_ReusesConnections sends rounds of parallel
+// requests, as a parallel apply does, and checks that the tuned transport
+// reuses its connections where http.DefaultTransport opens new ones.
+func TestNewDefaultHTTPClient_ReusesConnections(t *testing.T) {
+	const workers, rounds = 10, 20
+
+	stock := parallelConnections(t, stockTransport, workers, rounds)
+	tuned := parallelConnections(t, tunedTransport, workers, rounds)
+	t.Logf("connections opened: http.DefaultTransport %d, tuned %d", stock, tuned)
+
+	if tuned > 2*workers {
+		t.Errorf("tuned transport opened %d connections for %d workers", tuned, workers)
+	}
+	if tuned >= stock {
+		t.Errorf("tuned transport opened %d connections, http.DefaultTransport %d", tuned, stock)
+	}
+}
+
+func BenchmarkHTTPClient(b *testing.B) {
+	for name, newTransport := range map[string]func(*httptest.Server) *http.Transport{
+		"DefaultTransport": stockTransport,
+		"Tuned":            tunedTransport,
+	} {
+		b.Run(name, func(b *testing.B) {
+			server := newSlowTLSServer(b, nil)
+			client := &http.Client{Transport: newTransport(server)}
+			b.Cleanup(client.CloseIdleConnections)
+
+			// Ten concurrent requests, Terraform's default parallelism.
+			b.SetParallelism(max(1, 10/runtime.GOMAXPROCS(0)))
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					get(b, client, server.URL)
+				}
+			})
+		})
+	}
+}
+
+// parallelConnections sends rounds of parallel requests and returns how many
+// connections the server accepted.
+func parallelConnections(t *testing.T, newTransport func(*httptest.Server) *http.Transport, workers, rounds int) int64 {
+	t.Helper()
+
+	var opened atomic.Int64
+	server := newSlowTLSServer(t, &opened)
+	client := &http.Client{Transport: newTransport(server)}
+	defer client.CloseIdleConnections()
+
+	for range rounds {
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				get(t, client, server.URL)
+			}()
+		}
+		wg.Wait()
+	}
+
+	return opened.Load()
+}
+
+// newSlowTLSServer starts a TLS server that answers after a short delay, so
+// that parallel requests overlap. New connections are counted in opened.
+func newSlowTLSServer(tb testing.TB, opened *atomic.Int64) *httptest.Server {
+	tb.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond)
+		_, _ = io.WriteString(w, "{}")
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew && opened != nil {
+			opened.Add(1)
+		}
+	}
+	server.StartTLS()
+	tb.Cleanup(server.Close)
+
+	return server
+}
+
+// stockTransport returns a copy of http.DefaultTransport that trusts server.
+func stockTransport(server *httptest.Server) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+
+	return transport
+}
+
+// tunedTransport returns the newDefaultHTTPClient transport, trusting server.
+func tunedTransport(server *httptest.Server) *http.Transport {
+	transport, _ := newDefaultHTTPClient().Transport.(*http.Transport)
+	transport.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+
+	return transport
+}
+
+// get requests url and drains the response so the connection can be reused.
+func get(tb testing.TB, client *http.Client, url string) {
+	resp, err := client.Get(url)
+	if err != nil {
+		tb.Error(err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// privateKeyLandingPageConfigured is the private state key recording whether
+// the landing_page value in state came from the practitioner's configuration.
+const privateKeyLandingPageConfigured = "landing_page_configured"
+
+// Ensure interface compliance.
+var _ planmodifier.String = landingPageClearModifier{}
+
+// landingPageClearModifier plans an explicit disassociation when a previously
+// configured landing_page is removed from configuration, and keeps the server
+// value when the practitioner never set one.
+type landingPageClearModifier struct{}
+
+func (m landingPageClearModifier) Description(ctx context.Context) string {
+	return "Clears the landing page when it is removed from configuration, otherwise keeps the server value."
+}
+
+func (m landingPageClearModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m landingPageClearModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to compare against on create, and configured values are kept as is.
+	if req.State.Raw.IsNull() || !req.ConfigValue.IsNull() {
+		return
+	}
+
+	configured, diags := req.Private.GetKey(ctx, privateKeyLandingPageConfigured)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unset by the practitioner: plan an empty value so Update disassociates it.
+	if string(configured) == "true" {
+		resp.PlanValue = types.StringValue("")
+		return
+	}
+
+	// Never set: the value was assigned by the server, so keep it.
+	resp.PlanValue = req.StateValue
+}
+
+// landingPageConfiguredValue returns the private state value for
+// privateKeyLandingPageConfigured.
+func landingPageConfiguredValue(config types.String) []byte {
+	if config.IsNull() || config.IsUnknown() {
+		return []byte("false")
+	}
+
+	return []byte("true")
+}
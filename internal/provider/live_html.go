@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// liveHTMLDiagnostics reads the template of state from Infobip and warns when
+// its live html differs from the html plan sends, summarizing both, so
+// reviewers see what an apply replaces. It also tells whether the live html
+// was changed after the last refresh, such as in the Infobip UI, which the
+// plan diff does not show when planning without a refresh. It makes a single
+// API call, and a failed call is reported as a warning.
+func (r *EmailTemplateResource) liveHTMLDiagnostics(ctx, auth context.Context, state, plan EmailTemplateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Only known once the images are fetched, or the parent is read, on
+	// apply.
+	if plan.HtmlRaw.IsUnknown() || plan.HtmlRaw.IsNull() {
+		return diags
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(state.ID.ValueString(), "%d", &id); err != nil {
+		return diags
+	}
+
+	current, httpResponse, err := r.infobipClient.
+		EmailAPI.
+		GetEmailTemplate(auth).
+		ID(id).
+		Execute()
+
+	tflog.Info(ctx, fmt.Sprintf("HTTP Response Details: %+v\n", httpResponse))
+	if err == nil && isEmptyEmailTemplate(current) {
+		err = fmt.Errorf("the template was not found")
+	}
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("html"),
+			"Live Email Template HTML Not Compared",
+			fmt.Sprintf("Could not read email template ID %d to compare its live html with the plan: %s", id, err),
+		)
+		return diags
+	}
+
+	live := normalizeHTML(current.HTML)
+	if live == normalizeHTML(plan.HtmlRaw.ValueString()) {
+		return diags
+	}
+
+	detail := fmt.Sprintf("Infobip currently stores html of %s, and this plan sends html of %s.",
+		htmlSummary(current.HTML), htmlSummary(plan.HtmlRaw.ValueString()))
+	if live != normalizeHTML(state.Html.ValueString()) {
+		detail += " The live html was changed outside Terraform after the last refresh, such as in the Infobip UI, " +
+			"so the plan diff does not show it; applying overwrites that change."
+	}
+	diags.AddAttributeWarning(path.Root("html"), "Live Email Template HTML Differs", detail)
+
+	return diags
+}
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_CompareLiveHTML(t *testing.T) {
+	const (
+		original = "<html><body><h1>Hi</h1></body></html>"
+		edited   = "<html><body><h1>Hi</h1><p>Added in the UI</p></body></html>"
+		changed  = "<html><body><h1>Hello</h1></body></html>"
+	)
+
+	cases := map[string]struct {
+		enabled    bool
+		outOfBand  bool
+		html       string
+		wantDetail []string
+	}{
+		"out-of-band edit": {
+			enabled:    true,
+			outOfBand:  true,
+			html:       original,
+			wantDetail: []string{htmlSummary(edited), htmlSummary(original), "changed outside Terraform"},
+		},
+		"planned change": {
+			enabled:    true,
+			html:       changed,
+			wantDetail: []string{htmlSummary(original), htmlSummary(changed)},
+		},
+		"unchanged": {
+			enabled: true,
+			html:    original,
+		},
+		"disabled": {
+			outOfBand: true,
+			html:      original,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, map[string]tftypes.Value{"compare_live_html": tfBool(tc.enabled)})
+			r := h.resource("pocinfobipemails_email_template")
+
+			if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString(original)})); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+			if tc.outOfBand {
+				mock.mu.Lock()
+				mock.templates[1].HTML = edited
+				mock.mu.Unlock()
+			}
+
+			// Plan without refreshing, as with -refresh=false.
+			gets := countRequests(mock, "GET /email/1/templates/1")
+			diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"html": tfString(tc.html)}))
+			if hasError(diags) {
+				t.Fatalf("update: %s", diagnosticsString(diags))
+			}
+
+			got := diagnosticsString(diags)
+			if len(tc.wantDetail) == 0 {
+				if strings.Contains(got, "Live Email Template HTML Differs") {
+					t.Errorf("diagnostics = %s, want no live html warning", got)
+				}
+			}
+			for _, want := range tc.wantDetail {
+				if !strings.Contains(got, want) {
+					t.Errorf("diagnostics = %s, want them to contain %q", got, want)
+				}
+			}
+			if !tc.enabled && countRequests(mock, "GET /email/1/templates/1") != gets {
+				t.Errorf("template read while planning without compare_live_html")
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactLogs returns a child of ctx whose log messages and field values have
+// every match of patterns masked, so tokens embedded in html or tracking URLs
+// do not reach the logs. Masking is bound to the context, so every method
+// that logs must derive its context from redactLogs.
+func redactLogs(ctx context.Context, patterns []*regexp.Regexp) context.Context {
+	if len(patterns) == 0 {
+		return ctx
+	}
+
+	ctx = tflog.MaskMessageRegexes(ctx, patterns...)
+
+	return tflog.MaskAllFieldValuesRegexes(ctx, patterns...)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactLogs(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = redactLogs(ctx, []*regexp.Regexp{regexp.MustCompile(`token=[A-Za-z0-9]+`)})
+
+	tflog.Debug(ctx, `Sending html <a href="https://t.example.com/c?token=abc123">`, map[string]any{
+		"url": "https://t.example.com/c?token=def456&id=7",
+	})
+
+	logged := output.String()
+	for _, secret := range []string{"abc123", "def456"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log leaks %q: %s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "id=7") {
+		t.Errorf("log lost the unmatched text: %s", logged)
+	}
+}
+
+func TestEmailTemplateResource_LogRedactPatterns(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Launch secret-42", Subject: "Launch"})
+	h := newTestHarness(t, mock, map[string]tftypes.Value{
+		"log_redact_patterns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tfString(`secret-[0-9]+`)}),
+	})
+	var output bytes.Buffer
+	h.ctx = tflogtest.RootLogger(context.Background(), &output)
+	r := h.resource("pocinfobipemails_email_template")
+
+	// Adopting a template logs its name.
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{
+		"name":              tfString("Launch secret-42"),
+		"create_if_missing": tfBool(true),
+	})); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	logged := output.String()
+	if !strings.Contains(logged, "Adopting existing email template") {
+		t.Fatalf("expected the adoption to be logged: %s", logged)
+	}
+	if strings.Contains(logged, "secret-42") {
+		t.Errorf("log leaks the matched text: %s", logged)
+	}
+}
+
+func TestProviderConfigure_InvalidLogRedactPattern(t *testing.T) {
+	_, diags := configureTestHarness(t, newMockInfobip(t), map[string]tftypes.Value{
+		"log_redact_patterns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tfString(`ok`), tfString(`(unclosed`)}),
+	})
+	if !strings.Contains(diagnosticsString(diags), "log_redact_patterns[1] is not a valid regular expression") {
+		t.Errorf("diagnostics = %s, want an invalid pattern error", diagnosticsString(diags))
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import "sync"
+
+// managedIDTracker records the email template ids read by resources during a
+// run, to detect two resource blocks that manage the same template and undo
+// each other's changes. Terraform refreshes every resource instance once per
+// plan, in a single provider process, so an id read twice belongs to two
+// instances. It is best effort: templates managed by other provider
+// configurations or workspaces, or plans that skip the refresh, are not
+// covered. A nil tracker records nothing.
+type managedIDTracker struct {
+	mu    sync.Mutex
+	reads map[string]int
+}
+
+// claim records a read of id and reports whether it was already read.
+func (t *managedIDTracker) claim(id string) bool {
+	if t == nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.reads == nil {
+		t.reads = map[string]int{}
+	}
+	t.reads[id]++
+
+	return t.reads[id] > 1
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mergedValue returns the value to send for an optional field on update.
+// With merge_unmanaged_fields, a field that is not configured is sent as
+// currently stored by Infobip, such as a value set in the UI, instead of
+// being cleared.
+func mergedValue(merge bool, planned, prior types.String, current string) string {
+	if merge && planned.IsNull() {
+		return current
+	}
+
+	return changedValue(planned, prior, current)
+}
+
+// clearUnmanagedFields unsets the optional fields of m that managed leaves
+// unset, after m was mapped from the API, when merge_unmanaged_fields is set.
+// Unmanaged fields then stay unset in state whatever Infobip stores, so
+// values set in the UI do not cause a diff.
+func clearUnmanagedFields(ctx context.Context, m *EmailTemplateResourceModel, managed EmailTemplateResourceModel) {
+	if !m.MergeUnmanagedFields.ValueBool() {
+		return
+	}
+
+	if managed.ReplyTo.IsNull() {
+		m.ReplyTo = types.StringNull()
+	}
+	if effectivePreheader(ctx, managed).IsNull() {
+		m.Preheader = types.StringNull()
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &metricsTransport{}
+
+// metricsIDSegment matches the numeric path segments of an Infobip API URL,
+// such as template ids, so calls are grouped by operation.
+var metricsIDSegment = regexp.MustCompile(`^\d+$`)
+
+// providerMetrics accumulates counters of the Infobip API calls made during
+// a run and writes them as a JSON summary to a file. Terraform stops the
+// provider process without notice, so the summary is rewritten after every
+// call and holds the totals of the run when the process exits. A nil
+// *providerMetrics records nothing.
+type providerMetrics struct {
+	path string
+
+	calls        atomic.Int64
+	errors       atomic.Int64
+	retries      atomic.Int64
+	latencyNanos atomic.Int64
+
+	mu          sync.Mutex
+	byOperation map[string]int64
+}
+
+// metricsSummary is the JSON document written to metrics_file.
+type metricsSummary struct {
+	Calls            int64            `json:"calls"`
+	CallsByOperation map[string]int64 `json:"calls_by_operation"`
+	Errors           int64            `json:"errors"`
+	Retries          int64            `json:"retries"`
+	TotalLatencyMs   int64            `json:"total_latency_ms"`
+}
+
+func newProviderMetrics(path string) *providerMetrics {
+	return &providerMetrics{
+		path:        path,
+		byOperation: map[string]int64{},
+	}
+}
+
+// recordCall counts a call of operation that took latency. failed is set for
+// calls that got no response.
+func (m *providerMetrics) recordCall(operation string, latency time.Duration, failed bool) {
+	if m == nil {
+		return
+	}
+
+	m.calls.Add(1)
+	m.latencyNanos.Add(int64(latency))
+	if failed {
+		m.errors.Add(1)
+	}
+
+	m.mu.Lock()
+	m.byOperation[operation]++
+	m.mu.Unlock()
+}
+
+// recordRetry counts a retry of a call after a transient network error.
+func (m *providerMetrics) recordRetry() {
+	if m == nil {
+		return
+	}
+
+	m.retries.Add(1)
+}
+
+func (m *providerMetrics) summary() metricsSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.summaryLocked()
+}
+
+// flush writes the summary to the metrics file. The file is replaced
+// atomically, so it is never left half written.
+func (m *providerMetrics) flush() error {
+	if m == nil || m.path == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := json.MarshalIndent(m.summaryLocked(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), filepath.Base(m.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), m.path)
+}
+
+// summaryLocked is summary for callers that hold mu.
+func (m *providerMetrics) summaryLocked() metricsSummary {
+	byOperation := make(map[string]int64, len(m.byOperation))
+	for operation, calls := range m.byOperation {
+		byOperation[operation] = calls
+	}
+
+	return metricsSummary{
+		Calls:            m.calls.Load(),
+		CallsByOperation: byOperation,
+		Errors:           m.errors.Load(),
+		Retries:          m.retries.Load(),
+		TotalLatencyMs:   time.Duration(m.latencyNanos.Load()).Milliseconds(),
+	}
+}
+
+// metricsOperation names the operation of req, such as
+// "GET /email/2/templates/{id}".
+func metricsOperation(req *http.Request) string {
+	// Infobip API paths start with "/<product>/<version>", and the version is
+	// kept.
+	segments := strings.Split(req.URL.Path, "/")
+	for i := 3; i < len(segments); i++ {
+		if metricsIDSegment.MatchString(segments[i]) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return req.Method + " " + strings.Join(segments, "/")
+}
+
+// metricsTransport records every Infobip API call, including its retries, in
+// metrics and flushes them to the metrics file.
+type metricsTransport struct {
+	next    http.RoundTripper
+	metrics *providerMetrics
+}
+
+func newMetricsTransport(next http.RoundTripper, metrics *providerMetrics) *metricsTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &metricsTransport{next: next, metrics: metrics}
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	t.metrics.recordCall(metricsOperation(req), time.Since(start), err != nil)
+	// The metrics are best effort and never fail a call.
+	_ = t.metrics.flush()
+
+	return resp, err
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func readMetricsFile(t *testing.T, path string) metricsSummary {
+	t.Helper()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading metrics file: %s", err)
+	}
+	var summary metricsSummary
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		t.Fatalf("decoding metrics file: %s\n%s", err, raw)
+	}
+
+	return summary
+}
+
+func TestMetrics_CountsCalls(t *testing.T) {
+	mock := newMockInfobip(t)
+	metricsFile := filepath.Join(t.TempDir(), "metrics.json")
+	h := newTestHarness(t, mock, map[string]tftypes.Value{
+		"metrics_file": tfString(metricsFile),
+	})
+
+	// Configure performs a health check request.
+	before := readMetricsFile(t, metricsFile)
+	if before.Calls != int64(len(mock.requests)) {
+		t.Errorf("calls after configure = %d, want %d", before.Calls, len(mock.requests))
+	}
+
+	r := h.resource("pocinfobipemails_email_template")
+	if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+
+	after := readMetricsFile(t, metricsFile)
+	if after.Calls != int64(len(mock.requests)) {
+		t.Errorf("calls = %d, want %d", after.Calls, len(mock.requests))
+	}
+	if after.Calls <= before.Calls {
+		t.Errorf("calls = %d, want more than the %d after configure", after.Calls, before.Calls)
+	}
+	var byOperation int64
+	for _, calls := range after.CallsByOperation {
+		byOperation += calls
+	}
+	if byOperation != after.Calls {
+		t.Errorf("calls by operation add up to %d, want %d: %v", byOperation, after.Calls, after.CallsByOperation)
+	}
+	if after.CallsByOperation["GET /email/1/templates/{id}"] == 0 {
+		t.Errorf("calls by operation = %v, want the refresh grouped under GET /email/1/templates/{id}", after.CallsByOperation)
+	}
+	if after.Errors != 0 || after.Retries != 0 {
+		t.Errorf("errors = %d, retries = %d, want none", after.Errors, after.Retries)
+	}
+}
+
+func TestMetrics_CountsRetries(t *testing.T) {
+	fastNetworkRetries(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	metrics := newProviderMetrics("")
+	flaky := &flakyTransport{err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, failures: 2}
+	client := &http.Client{Transport: newMetricsTransport(newRetryTransport(flaky, metrics, defaultRetryLimits()), metrics)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/email/1/templates")
+		if err != nil {
+			t.Fatalf("request %d: %s", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	summary := metrics.summary()
+	if summary.Calls != 2 || summary.CallsByOperation["GET /email/1/templates"] != 2 {
+		t.Errorf("calls = %d, by operation %v, want 2 GET /email/1/templates", summary.Calls, summary.CallsByOperation)
+	}
+	if summary.Retries != 2 {
+		t.Errorf("retries = %d, want 2", summary.Retries)
+	}
+	if summary.Errors != 0 {
+		t.Errorf("errors = %d, want 0", summary.Errors)
+	}
+}
+
+func TestMetricsOperation(t *testing.T) {
+	cases := map[string]string{
+		"/email/1/templates":           "GET /email/1/templates",
+		"/email/1/templates/42":        "GET /email/1/templates/{id}",
+		"/email/1/templates/42/7":      "GET /email/1/templates/{id}/{id}",
+		"/email/1/templates/42/images": "GET /email/1/templates/{id}/images",
+	}
+	for path, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if got := metricsOperation(req); got != want {
+			t.Errorf("metricsOperation(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
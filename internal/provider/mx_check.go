@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// skipMXCheckEnv, set to a true value, disables check_mx, for example on
+// machines without DNS access or in tests.
+const skipMXCheckEnv = "POCINFOBIPEMAILS_SKIP_MX_CHECK"
+
+// mxLookupTimeout bounds a single MX lookup.
+var mxLookupTimeout = 5 * time.Second
+
+// mxResolver looks up the MX records of a domain. *net.Resolver implements
+// it.
+type mxResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// mxChecker checks that the from domain of a template has MX records, so
+// bounces and replies to it can be delivered.
+type mxChecker struct {
+	resolver mxResolver
+	// strict reports a domain without MX records as an error instead of a
+	// warning.
+	strict bool
+}
+
+// check looks up the MX records of the domain of from. A domain without MX
+// records is reported on the from attribute as a warning, or as an error
+// when strict. A lookup that fails or times out is only a warning, so an
+// unreachable DNS server does not block plans.
+func (c *mxChecker) check(ctx context.Context, from types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c == nil || from.IsUnknown() || from.IsNull() {
+		return diags
+	}
+
+	domain := addressDomain(emailAddress(from.ValueString()))
+	if domain == "" {
+		return diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mxLookupTimeout)
+	defer cancel()
+
+	records, err := c.resolver.LookupMX(ctx, domain)
+	var dnsErr *net.DNSError
+	if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		diags.AddAttributeWarning(
+			path.Root("from"),
+			"MX Lookup Failed",
+			fmt.Sprintf("Could not look up the MX records of %q, so the from domain was not checked: %s", domain, err),
+		)
+		return diags
+	}
+	if len(records) > 0 {
+		return diags
+	}
+
+	detail := fmt.Sprintf("The domain of from, %q, has no MX records, so bounces and replies to it cannot be delivered. "+
+		"Use a sender domain that receives email, or add MX records to it.", domain)
+	if c.strict {
+		diags.AddAttributeError(path.Root("from"), "From Domain Has No MX Records", detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("from"), "From Domain Has No MX Records", detail)
+	}
+
+	return diags
+}
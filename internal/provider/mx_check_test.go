@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_CheckMX(t *testing.T) {
+	cases := map[string]struct {
+		from    string
+		domain  string
+		strict  bool
+		warning bool
+		error   bool
+	}{
+		"domain with MX":           {from: "Sender <sender@example.com>", domain: "example.com"},
+		"domain without MX":        {from: "sender@nomx.example", domain: "nomx.example", warning: true},
+		"strict domain with MX":    {from: "sender@example.com", domain: "example.com", strict: true},
+		"strict domain without MX": {from: "sender@nomx.example", domain: "nomx.example", strict: true, error: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			mock.mx["example.com"] = []string{"mx1.example.com.", "mx2.example.com."}
+			h := newTestHarness(t, mock, map[string]tftypes.Value{
+				"check_mx":        tfBool(true),
+				"check_mx_strict": tfBool(tc.strict),
+			})
+			r := h.resource("pocinfobipemails_email_template")
+
+			diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"from": tfString(tc.from)}))
+			if hasError(diags) != tc.error {
+				t.Fatalf("diagnostics = %s, want error %t", diagnosticsString(diags), tc.error)
+			}
+			if got := strings.Contains(diagnosticsString(diags), "From Domain Has No MX Records"); got != (tc.warning || tc.error) {
+				t.Errorf("diagnostics = %s, want From Domain Has No MX Records %t", diagnosticsString(diags), tc.warning || tc.error)
+			}
+			if len(mock.mxLookups) == 0 || mock.mxLookups[0] != tc.domain {
+				t.Errorf("MX lookups = %v, want %s", mock.mxLookups, tc.domain)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_CheckMXSkipped(t *testing.T) {
+	t.Setenv(skipMXCheckEnv, "true")
+
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, map[string]tftypes.Value{
+		"check_mx":        tfBool(true),
+		"check_mx_strict": tfBool(true),
+	})
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{"from": tfString("sender@nomx.example")}))
+	if hasError(diags) {
+		t.Fatalf("apply: %s", diagnosticsString(diags))
+	}
+	if len(mock.mxLookups) != 0 {
+		t.Errorf("MX lookups = %v, want none with %s set", mock.mxLookups, skipMXCheckEnv)
+	}
+}
+
+// mxResolverFunc adapts a function to mxResolver.
+type mxResolverFunc func(ctx context.Context, name string) ([]*net.MX, error)
+
+func (f mxResolverFunc) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	return f(ctx, name)
+}
+
+func TestMXCheckerLookupFailure(t *testing.T) {
+	timeout := mxResolverFunc(func(ctx context.Context, name string) ([]*net.MX, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("MX lookup has no deadline")
+		}
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	})
+	c := &mxChecker{resolver: timeout, strict: true}
+
+	diags := c.check(context.Background(), types.StringValue("sender@example.com"))
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("diagnostics = %v, want a single warning", diags)
+	}
+	if got := diags.Warnings()[0].Summary(); got != "MX Lookup Failed" {
+		t.Errorf("warning = %q, want MX Lookup Failed", got)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+)
+
+// organizationHeader carries the Infobip organization (sub-account) a request
+// is scoped to.
+const organizationHeader = "X-Infobip-Organization-Id"
+
+// organizationContextKey is the context key of the organization ID set by
+// infobipAuthContext.
+type organizationContextKey struct{}
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &organizationTransport{}
+
+// organizationTransport sets organizationHeader on requests whose context
+// carries an organization ID. The Infobip client has no per-request headers,
+// so the ID travels in the auth context like the API key.
+type organizationTransport struct {
+	next http.RoundTripper
+}
+
+func newOrganizationTransport(next http.RoundTripper) *organizationTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &organizationTransport{next: next}
+}
+
+func (t *organizationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	organizationID, _ := req.Context().Value(organizationContextKey{}).(string)
+	if organizationID == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the request.
+	req = req.Clone(req.Context())
+	req.Header.Set(organizationHeader, organizationID)
+
+	return t.next.RoundTrip(req)
+}
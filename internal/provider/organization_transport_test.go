@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOrganizationID(t *testing.T) {
+	cases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		want           string
+	}{
+		"unset": {},
+		"set": {
+			providerConfig: map[string]tftypes.Value{"organization_id": tfString("org-42")},
+			want:           "org-42",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			var (
+				mu      sync.Mutex
+				headers []string
+			)
+			mock.onRequest = func(r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				headers = append(headers, r.Header.Get(organizationHeader))
+			}
+
+			h := newTestHarness(t, mock, tc.providerConfig)
+			r := h.resource("pocinfobipemails_email_template")
+			if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(headers) == 0 {
+				t.Fatal("no requests were made")
+			}
+			for i, got := range headers {
+				if got != tc.want {
+					t.Errorf("request %d %s header = %q, want %q", i, organizationHeader, got, tc.want)
+				}
+			}
+		})
+	}
+}
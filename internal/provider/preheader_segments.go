@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// joinPreheaderSegments joins preheader_segments into the single preheader
+// Infobip stores: segments are trimmed, empty ones are dropped and the rest
+// are separated by a space. The result is null or unknown like the list.
+func joinPreheaderSegments(ctx context.Context, segments types.List) types.String {
+	if segments.IsNull() {
+		return types.StringNull()
+	}
+	if segments.IsUnknown() {
+		return types.StringUnknown()
+	}
+
+	var values []types.String
+	if diags := segments.ElementsAs(ctx, &values, false); diags.HasError() {
+		return types.StringUnknown()
+	}
+
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		if value.IsUnknown() {
+			return types.StringUnknown()
+		}
+		if segment := strings.TrimSpace(value.ValueString()); segment != "" {
+			parts = append(parts, segment)
+		}
+	}
+
+	return types.StringValue(strings.Join(parts, " "))
+}
+
+// effectivePreheader returns the preheader sent to Infobip for m: the joined
+// preheader_segments when set, preheader otherwise.
+func effectivePreheader(ctx context.Context, m EmailTemplateResourceModel) types.String {
+	if !m.PreheaderSegments.IsNull() {
+		return joinPreheaderSegments(ctx, m.PreheaderSegments)
+	}
+
+	return m.Preheader
+}
+
+// setPreheaderFromAPI maps the preheader stored by Infobip into m. With
+// preheader_segments set, preheader stays unset and the segments are kept
+// unless the stored preheader no longer matches them, which is drift.
+func setPreheaderFromAPI(ctx context.Context, m *EmailTemplateResourceModel, preheader string) {
+	m.EffectivePreheader = types.StringValue(preheader)
+	if m.PreheaderSegments.IsNull() {
+		m.Preheader = types.StringValue(preheader)
+		return
+	}
+
+	if joinPreheaderSegments(ctx, m.PreheaderSegments).ValueString() != preheader {
+		m.PreheaderSegments = types.ListValueMust(types.StringType, []attr.Value{types.StringValue(preheader)})
+	}
+}
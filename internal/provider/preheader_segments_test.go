@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestJoinPreheaderSegments(t *testing.T) {
+	cases := map[string]struct {
+		segments types.List
+		want     types.String
+	}{
+		"null": {
+			segments: types.ListNull(types.StringType),
+			want:     types.StringNull(),
+		},
+		"unknown": {
+			segments: types.ListUnknown(types.StringType),
+			want:     types.StringUnknown(),
+		},
+		"unknown segment": {
+			segments: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Hi"), types.StringUnknown()}),
+			want:     types.StringUnknown(),
+		},
+		"single": {
+			segments: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Hello")}),
+			want:     types.StringValue("Hello"),
+		},
+		"multiple": {
+			segments: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue(" Spring sale "),
+				types.StringValue(""),
+				types.StringValue("up to 50% off\n"),
+			}),
+			want: types.StringValue("Spring sale up to 50% off"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := joinPreheaderSegments(context.Background(), tc.segments); !got.Equal(tc.want) {
+				t.Errorf("joinPreheaderSegments() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEmailTemplateResource_PreheaderSegments(t *testing.T) {
+	mock := newMockInfobip(t)
+	h := newTestHarness(t, mock, nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"preheader_segments": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tfString("Spring sale"),
+			tfString("up to 50% off"),
+		}),
+	})
+	diags := r.apply(config)
+	if hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+	if !strings.Contains(diagnosticsString(diags), "Preheader Segments Collapsed") {
+		t.Errorf("diagnostics = %s, want a collapsed segments warning", diagnosticsString(diags))
+	}
+
+	if got := mock.lastForm()["preheader"]; got != "Spring sale up to 50% off" {
+		t.Errorf("sent preheader = %q, want the joined segments", got)
+	}
+	if got := r.stringAttr("effective_preheader"); got != "Spring sale up to 50% off" {
+		t.Errorf("effective_preheader = %q, want the joined segments", got)
+	}
+	if !r.attr("preheader").IsNull() {
+		t.Errorf("preheader = %s, want null", r.attr("preheader"))
+	}
+
+	// A preheader changed outside of Terraform shows up as drift.
+	for _, tmpl := range mock.templates {
+		tmpl.Preheader = "Changed in the UI"
+	}
+	if diags := r.refresh(); hasError(diags) {
+		t.Fatalf("refresh: %s", diagnosticsString(diags))
+	}
+	var segments []tftypes.Value
+	_ = r.attr("preheader_segments").As(&segments)
+	if len(segments) != 1 || !segments[0].Equal(tfString("Changed in the UI")) {
+		t.Errorf("preheader_segments = %v, want the stored preheader", segments)
+	}
+}
+
+func TestEmailTemplateResource_PreheaderSegmentsConflict(t *testing.T) {
+	h := newTestHarness(t, newMockInfobip(t), nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	diags := r.validate(testEmailTemplateConfig(map[string]tftypes.Value{
+		"preheader": tfString("Hello"),
+		"preheader_segments": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tfString("Hello"),
+		}),
+	}))
+	if !strings.Contains(diagnosticsString(diags), "Conflicting Preheader Attributes") {
+		t.Errorf("diagnostics = %s, want a conflict error", diagnosticsString(diags))
+	}
+}
@@ -4,10 +4,26 @@
 package provider
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 )
 
@@ -32,3 +48,830 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestProtocolV6ServerFactory(t *testing.T) {
+	factory := ProtocolV6ServerFactory("test")
+
+	schemas, err := factory().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %s", err)
+	}
+	if hasError(schemas.Diagnostics) {
+		t.Fatalf("GetProviderSchema diagnostics: %s", diagnosticsString(schemas.Diagnostics))
+	}
+	if _, ok := schemas.ResourceSchemas["pocinfobipemails_email_template"]; !ok {
+		t.Errorf("factory provider is missing the email template resource")
+	}
+
+	if tf6server.New("registry.terraform.io/framebassman/pocinfobipemails", factory()) == nil {
+		t.Errorf("tf6server.New returned no gRPC server")
+	}
+}
+
+func TestProviderConfigure_AuthSchemeKey(t *testing.T) {
+	for _, providerConfig := range []map[string]tftypes.Value{nil, {"auth_scheme_key": tfString(defaultAuthSchemeKey)}} {
+		mock := newMockInfobip(t)
+		newTestHarness(t, mock, providerConfig)
+		if got := mock.firstAuthorization(); got != "App test-key" {
+			t.Fatalf("Authorization with auth_scheme_key %v = %q, want %q", providerConfig["auth_scheme_key"], got, "App test-key")
+		}
+	}
+
+	// The client only reads the APIKeyHeader key, so another one would
+	// send requests without credentials.
+	mock := newMockInfobip(t)
+	_, diags := configureTestHarness(t, mock, map[string]tftypes.Value{
+		"auth_scheme_key": tfString("CustomScheme"),
+	})
+	if !strings.Contains(diagnosticsString(diags), "Unsupported auth scheme key") {
+		t.Fatalf("diagnostics = %s, want an unsupported auth scheme key error", diagnosticsString(diags))
+	}
+	for i, authorization := range mock.authorizations {
+		if authorization == "" {
+			t.Errorf("request %s sent without an Authorization header", mock.requests[i])
+		}
+	}
+}
+
+// mockInfobip is an in-memory stand-in for the Infobip email templates API.
+type mockInfobip struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	nextID    int64
+	templates map[int64]*email.CreateEmailTemplateResponse
+	// requests records every request as "METHOD /path".
+	requests []string
+	// authorizations records the Authorization header of every request.
+	authorizations []string
+	// forms records the submitted form of every create and update request.
+	forms []map[string]string
+	// failGet makes GET requests for these template IDs fail with a 500.
+	failGet map[int64]bool
+	// emptyGet makes GET requests for these template IDs answer 200 with an
+	// empty body.
+	emptyGet map[int64]bool
+	// failPut makes the next PUT requests for these template IDs apply the
+	// form and then fail with a 500, one per count, like a partially applied
+	// update.
+	failPut map[int64]int
+	// createID, when set, is the ID reported by create responses instead of
+	// the ID of the stored template.
+	createID *int64
+	// previewAfterGets, when positive, delays the preview image like its
+	// asynchronous generation: image_preview_url is left empty by creates and
+	// updates and populated by the GET request of that number for the
+	// template. getCounts counts the GET requests per template.
+	previewAfterGets int
+	getCounts        map[int64]int
+	// domains holds the sending domains of the account.
+	domains map[string]mockDomain
+	// suppressions maps an email address to the suppression types it is on.
+	suppressions map[string][]string
+	// sends records the form of every send request.
+	sends []map[string][]string
+	// mx maps a domain to its MX hosts for check_mx lookups. Domains not in
+	// it have no MX records.
+	mx map[string][]string
+	// mxLookups records the domain of every MX lookup.
+	mxLookups []string
+	// etags makes template responses carry an ETag of the template content,
+	// and PUT requests whose If-Match differs from it fail with a 412.
+	etags bool
+	// account, when set, is served by the account status endpoints, which
+	// are not found otherwise.
+	account *mockAccount
+	// headers are added to every response.
+	headers http.Header
+
+	// onRequest, when set, is called with every request before it is served.
+	onRequest func(*http.Request)
+
+	// delay is applied to every request before it is served.
+	delay       time.Duration
+	inFlight    atomic.Int64
+	maxInFlight atomic.Int64
+}
+
+func newMockInfobip(t *testing.T) *mockInfobip {
+	t.Helper()
+
+	m := &mockInfobip{
+		nextID:    1,
+		templates: map[int64]*email.CreateEmailTemplateResponse{},
+		failGet:   map[int64]bool{},
+		emptyGet:  map[int64]bool{},
+		failPut:   map[int64]int{},
+		getCounts: map[int64]int{},
+
+		domains:      map[string]mockDomain{},
+		suppressions: map[string][]string{},
+		mx:           map[string][]string{},
+	}
+	m.Server = httptest.NewTLSServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.Close)
+
+	return m
+}
+
+// host returns the value the provider expects in base_url.
+func (m *mockInfobip) host() string {
+	return strings.TrimPrefix(m.URL, "https://")
+}
+
+func (m *mockInfobip) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	n := m.inFlight.Add(1)
+	defer m.inFlight.Add(-1)
+	for {
+		highest := m.maxInFlight.Load()
+		if n <= highest || m.maxInFlight.CompareAndSwap(highest, n) {
+			break
+		}
+	}
+	time.Sleep(m.delay)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, r.Method+" "+r.URL.Path)
+	m.authorizations = append(m.authorizations, r.Header.Get("Authorization"))
+	if m.onRequest != nil {
+		m.onRequest(r)
+	}
+	for name, values := range m.headers {
+		w.Header()[name] = values
+	}
+
+	if name, ok := strings.CutPrefix(r.URL.Path, "/email/1/domains/"); ok {
+		m.serveDomain(w, name)
+		return
+	}
+	if r.URL.Path == "/email/1/suppressions" {
+		m.serveSuppressions(w, r)
+		return
+	}
+	if m.account != nil && r.URL.Path == accountPath {
+		writeJSON(w, http.StatusOK, map[string]any{"key": "account-key", "enabled": m.account.enabled})
+		return
+	}
+	if m.account != nil && r.URL.Path == accountBalancePath {
+		writeJSON(w, http.StatusOK, map[string]any{"balance": m.account.balance, "currency": "EUR"})
+		return
+	}
+	if r.URL.Path == "/email/3/send" {
+		m.serveSend(w, r)
+		return
+	}
+
+	const prefix = "/email/1/templates"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.NotFound(w, r)
+		return
+	}
+
+	rest := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if rest == "" {
+		switch r.Method {
+		case http.MethodGet:
+			m.writeList(w, r)
+		case http.MethodPost:
+			// Templates created over the API use the code editor.
+			tmpl := &email.CreateEmailTemplateResponse{ID: m.nextID, IsHTMLEditable: true}
+			m.nextID++
+			m.applyForm(r, tmpl)
+			m.templates[tmpl.ID] = tmpl
+			m.setETag(w, tmpl)
+			if m.createID != nil {
+				reported := *tmpl
+				reported.ID = *m.createID
+				writeJSON(w, http.StatusOK, &reported)
+				return
+			}
+			writeJSON(w, http.StatusOK, tmpl)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	id, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	tmpl, ok := m.templates[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]any{})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if m.failGet[id] {
+			writeJSON(w, http.StatusInternalServerError, map[string]any{})
+			return
+		}
+		if m.emptyGet[id] {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		m.getCounts[id]++
+		if m.previewAfterGets > 0 && m.getCounts[id] >= m.previewAfterGets {
+			tmpl.ImagePreviewURL = fmt.Sprintf("https://preview.example.com/%d.png", id)
+		}
+		m.setETag(w, tmpl)
+		writeJSON(w, http.StatusOK, tmpl)
+	case http.MethodPut:
+		if ifMatch := r.Header.Get("If-Match"); m.etags && ifMatch != "" && ifMatch != templateETag(tmpl) {
+			writeJSON(w, http.StatusPreconditionFailed, map[string]any{})
+			return
+		}
+		m.applyForm(r, tmpl)
+		if m.failPut[id] > 0 {
+			m.failPut[id]--
+			writeJSON(w, http.StatusInternalServerError, map[string]any{})
+			return
+		}
+		m.setETag(w, tmpl)
+		writeJSON(w, http.StatusOK, tmpl)
+	case http.MethodDelete:
+		delete(m.templates, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// mockAccount is the status of the account in the mock.
+type mockAccount struct {
+	enabled bool
+	balance float64
+}
+
+// mockDomain is the state of a sending domain in the mock.
+type mockDomain struct {
+	active  bool
+	blocked bool
+}
+
+func (m *mockInfobip) serveDomain(w http.ResponseWriter, name string) {
+	domain, ok := m.domains[name]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]any{})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"domainId":   1,
+		"domainName": name,
+		"active":     domain.active,
+		"blocked":    domain.blocked,
+	})
+}
+
+func (m *mockInfobip) serveSuppressions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	address := query.Get("emailAddress")
+
+	results := []map[string]any{}
+	for _, suppressionType := range m.suppressions[address] {
+		if suppressionType == query.Get("type") {
+			results = append(results, map[string]any{
+				"domainName":   query.Get("domainName"),
+				"emailAddress": address,
+				"type":         suppressionType,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": results})
+}
+
+// writeList answers a list request with the page of templates, ordered by
+// ID, selected by the page and size query parameters.
+func (m *mockInfobip) writeList(w http.ResponseWriter, r *http.Request) {
+	ids := make([]int64, 0, len(m.templates))
+	for id := range m.templates {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, err := strconv.Atoi(r.URL.Query().Get("size"))
+	if err != nil || size < 1 {
+		size = 10
+	}
+	totalPages := (len(ids) + size - 1) / size
+
+	results := []map[string]any{}
+	for i := page * size; i < len(ids) && i < (page+1)*size; i++ {
+		tmpl := m.templates[ids[i]]
+		results = append(results, map[string]any{
+			"id":      tmpl.ID,
+			"name":    tmpl.Name,
+			"subject": tmpl.Subject,
+			"body":    tmpl.HTML,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"results": results,
+		"paging": map[string]any{
+			"page":         page,
+			"size":         size,
+			"totalPages":   totalPages,
+			"totalResults": len(ids),
+		},
+	})
+}
+
+// serveSend accepts a send of a stored template to every "to" address.
+func (m *mockInfobip) serveSend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	_ = r.ParseMultipartForm(1 << 20)
+	form := r.MultipartForm.Value
+	m.sends = append(m.sends, form)
+
+	templateID, _ := strconv.ParseInt(first(form["templateId"]), 10, 64)
+	if _, ok := m.templates[templateID]; !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"requestError": map[string]any{"serviceException": map[string]any{"messageId": "BAD_REQUEST", "text": "Template not found"}},
+		})
+		return
+	}
+
+	bulkID := fmt.Sprintf("bulk-%d", len(m.sends))
+	messages := []map[string]any{}
+	for i, to := range form["to"] {
+		messages = append(messages, map[string]any{
+			"to":        to,
+			"messageId": fmt.Sprintf("%s-%d", bulkID, i),
+			"status":    map[string]any{"groupId": 1, "groupName": "PENDING", "id": 26, "name": "PENDING_ACCEPTED"},
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"bulkId": bulkID, "messages": messages})
+}
+
+// first returns the first of values, or "" when there is none.
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (m *mockInfobip) applyForm(r *http.Request, tmpl *email.CreateEmailTemplateResponse) {
+	_ = r.ParseMultipartForm(1 << 20)
+
+	form := map[string]string{}
+	for k, v := range r.MultipartForm.Value {
+		form[k] = v[0]
+	}
+	m.forms = append(m.forms, form)
+
+	tmpl.Name = form["name"]
+	tmpl.From = form["from"]
+	tmpl.ReplyTo = form["replyTo"]
+	tmpl.Subject = form["subject"]
+	tmpl.Preheader = form["preheader"]
+	tmpl.HTML = form["html"]
+	tmpl.LandingPageID = form["landingPage"]
+	if m.previewAfterGets == 0 {
+		tmpl.ImagePreviewURL = fmt.Sprintf("https://preview.example.com/%d.png", tmpl.ID)
+	}
+}
+
+// LookupMX fakes DNS for check_mx, so tests do not depend on the network.
+func (m *mockInfobip) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mxLookups = append(m.mxLookups, name)
+	hosts, ok := m.mx[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	records := make([]*net.MX, 0, len(hosts))
+	for i, host := range hosts {
+		records = append(records, &net.MX{Host: host, Pref: uint16(10 * (i + 1))})
+	}
+
+	return records, nil
+}
+
+// firstAuthorization returns the Authorization header of the first request.
+func (m *mockInfobip) firstAuthorization() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.authorizations) == 0 {
+		return ""
+	}
+
+	return m.authorizations[0]
+}
+
+// lastForm returns the form submitted by the most recent create or update.
+func (m *mockInfobip) lastForm() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.forms) == 0 {
+		return nil
+	}
+
+	return m.forms[len(m.forms)-1]
+}
+
+// setETag sets the ETag of tmpl on w when the mock sends ETags.
+func (m *mockInfobip) setETag(w http.ResponseWriter, tmpl *email.CreateEmailTemplateResponse) {
+	if m.etags {
+		w.Header().Set("ETag", templateETag(tmpl))
+	}
+}
+
+// templateETag derives an ETag from the content of tmpl, so any change to it
+// changes the ETag.
+func templateETag(tmpl *email.CreateEmailTemplateResponse) string {
+	body, _ := json.Marshal(tmpl)
+
+	return fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// testHarness drives the provider over the plugin protocol, standing in for
+// the Terraform CLI in unit tests.
+type testHarness struct {
+	t       *testing.T
+	server  tfprotov6.ProviderServer
+	schemas *tfprotov6.GetProviderSchemaResponse
+	// ctx is passed to the resource and data source calls, so a test can
+	// capture their logs with tflogtest.
+	ctx context.Context
+}
+
+// newTestHarness configures the provider against the mock with the given
+// extra provider attributes, failing the test on error diagnostics.
+func newTestHarness(t *testing.T, mock *mockInfobip, providerConfig map[string]tftypes.Value) *testHarness {
+	t.Helper()
+
+	h, diags := configureTestHarness(t, mock, providerConfig)
+	if hasError(diags) {
+		t.Fatalf("ConfigureProvider diagnostics: %s", diagnosticsString(diags))
+	}
+
+	return h
+}
+
+// configureTestHarness configures the provider against the mock and returns
+// the diagnostics of ConfigureProvider.
+func configureTestHarness(t *testing.T, mock *mockInfobip, providerConfig map[string]tftypes.Value) (*testHarness, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	return configureVersionedTestHarness(t, mock, "test", providerConfig)
+}
+
+// configureVersionedTestHarness is configureTestHarness for a provider
+// reporting version.
+func configureVersionedTestHarness(t *testing.T, mock *mockInfobip, version string, providerConfig map[string]tftypes.Value) (*testHarness, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	p := &pocinfobipemailsProvider{version: version, httpClient: mock.Client(), mxResolver: mock}
+	h := &testHarness{t: t, server: providerserver.NewProtocol6(p)(), ctx: context.Background()}
+
+	schemas, err := h.server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %s", err)
+	}
+	h.schemas = schemas
+
+	attrs := map[string]tftypes.Value{
+		"base_url": tfString(mock.host()),
+		"api_key":  tfString("test-key"),
+	}
+	for k, v := range providerConfig {
+		attrs[k] = v
+	}
+
+	resp, err := h.server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		Config: h.dynamicValue(schemas.Provider, attrs),
+	})
+	if err != nil {
+		t.Fatalf("ConfigureProvider: %s", err)
+	}
+
+	return h, resp.Diagnostics
+}
+
+// dynamicValue builds an object for the schema, leaving unset attributes null.
+func (h *testHarness) dynamicValue(schema *tfprotov6.Schema, attrs map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	h.t.Helper()
+
+	dv, err := tfprotov6.NewDynamicValue(schema.ValueType(), objectValue(schema, attrs))
+	if err != nil {
+		h.t.Fatalf("NewDynamicValue: %s", err)
+	}
+
+	return &dv
+}
+
+func objectValue(schema *tfprotov6.Schema, attrs map[string]tftypes.Value) tftypes.Value {
+	typ := schema.ValueType().(tftypes.Object)
+	vals := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			vals[name] = v
+			continue
+		}
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	return tftypes.NewValue(typ, vals)
+}
+
+// addTemplate stores a template directly in the mock and returns its ID.
+func (m *mockInfobip) addTemplate(tmpl email.CreateEmailTemplateResponse) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tmpl.ID = m.nextID
+	m.nextID++
+	m.templates[tmpl.ID] = &tmpl
+
+	return tmpl.ID
+}
+
+// readDataSource reads the data source with the given configuration.
+func (h *testHarness) readDataSource(typeName string, config map[string]tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	h.t.Helper()
+
+	schema, ok := h.schemas.DataSourceSchemas[typeName]
+	if !ok {
+		h.t.Fatalf("unknown data source type %q", typeName)
+	}
+
+	resp, err := h.server.ReadDataSource(h.ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   h.dynamicValue(schema, config),
+	})
+	if err != nil {
+		h.t.Fatalf("ReadDataSource: %s", err)
+	}
+	if hasError(resp.Diagnostics) {
+		return tftypes.Value{}, resp.Diagnostics
+	}
+
+	state, err := resp.State.Unmarshal(schema.ValueType())
+	if err != nil {
+		h.t.Fatalf("Unmarshal: %s", err)
+	}
+
+	return state, resp.Diagnostics
+}
+
+// testResource tracks the state of a single resource instance across steps.
+type testResource struct {
+	h        *testHarness
+	typeName string
+	schema   *tfprotov6.Schema
+	state    tftypes.Value
+	private  []byte
+	// replaced counts the applies that were planned as a replacement.
+	replaced int
+	// planned is the planned state of the most recent apply.
+	planned tftypes.Value
+}
+
+func (h *testHarness) resource(typeName string) *testResource {
+	schema, ok := h.schemas.ResourceSchemas[typeName]
+	if !ok {
+		h.t.Fatalf("unknown resource type %q", typeName)
+	}
+
+	return &testResource{
+		h:        h,
+		typeName: typeName,
+		schema:   schema,
+		state:    tftypes.NewValue(schema.ValueType(), nil),
+	}
+}
+
+// apply plans and applies the given configuration, returning any diagnostics.
+func (r *testResource) apply(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	r.h.t.Helper()
+	ctx := r.h.ctx
+	typ := r.schema.ValueType()
+
+	configValue := objectValue(r.schema, config)
+	configDV := r.h.dynamicValue(r.schema, config)
+	priorDV, _ := tfprotov6.NewDynamicValue(typ, r.state)
+	proposedDV, _ := tfprotov6.NewDynamicValue(typ, r.proposedNewState(configValue))
+
+	planResp, err := r.h.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       &priorDV,
+		ProposedNewState: &proposedDV,
+		Config:           configDV,
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		r.h.t.Fatalf("PlanResourceChange: %s", err)
+	}
+	if hasError(planResp.Diagnostics) {
+		return planResp.Diagnostics
+	}
+
+	r.planned = r.unmarshal(planResp.PlannedState)
+
+	// Replace like Terraform does by default: destroy, then create.
+	if len(planResp.RequiresReplace) > 0 && !r.state.IsNull() {
+		r.replaced++
+		if diags := r.destroy(); hasError(diags) {
+			return append(planResp.Diagnostics, diags...)
+		}
+		return append(planResp.Diagnostics, r.apply(config)...)
+	}
+
+	applyResp, err := r.h.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     &priorDV,
+		PlannedState:   planResp.PlannedState,
+		Config:         configDV,
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil {
+		r.h.t.Fatalf("ApplyResourceChange: %s", err)
+	}
+
+	diags := append(planResp.Diagnostics, applyResp.Diagnostics...)
+	if hasError(applyResp.Diagnostics) {
+		return diags
+	}
+
+	r.state = r.unmarshal(applyResp.NewState)
+	r.private = applyResp.Private
+
+	return diags
+}
+
+// validate runs the resource config validation Terraform performs before
+// planning.
+func (r *testResource) validate(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	r.h.t.Helper()
+
+	resp, err := r.h.server.ValidateResourceConfig(r.h.ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: r.typeName,
+		Config:   r.h.dynamicValue(r.schema, config),
+	})
+	if err != nil {
+		r.h.t.Fatalf("ValidateResourceConfig: %s", err)
+	}
+
+	return resp.Diagnostics
+}
+
+// destroy applies the deletion of the resource.
+func (r *testResource) destroy() []*tfprotov6.Diagnostic {
+	r.h.t.Helper()
+	typ := r.schema.ValueType()
+
+	priorDV, _ := tfprotov6.NewDynamicValue(typ, r.state)
+	nullDV, _ := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+
+	resp, err := r.h.server.ApplyResourceChange(r.h.ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     &priorDV,
+		PlannedState:   &nullDV,
+		Config:         &nullDV,
+		PlannedPrivate: r.private,
+	})
+	if err != nil {
+		r.h.t.Fatalf("ApplyResourceChange: %s", err)
+	}
+	if hasError(resp.Diagnostics) {
+		return resp.Diagnostics
+	}
+
+	r.state = tftypes.NewValue(typ, nil)
+	r.private = nil
+
+	return resp.Diagnostics
+}
+
+// refresh reads the resource and stores the refreshed state.
+func (r *testResource) refresh() []*tfprotov6.Diagnostic {
+	r.h.t.Helper()
+
+	current, _ := tfprotov6.NewDynamicValue(r.schema.ValueType(), r.state)
+	resp, err := r.h.server.ReadResource(r.h.ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: &current,
+		Private:      r.private,
+	})
+	if err != nil {
+		r.h.t.Fatalf("ReadResource: %s", err)
+	}
+	if hasError(resp.Diagnostics) {
+		return resp.Diagnostics
+	}
+
+	r.state = r.unmarshal(resp.NewState)
+	r.private = resp.Private
+
+	return resp.Diagnostics
+}
+
+// proposedNewState mimics Terraform: configured values win, and computed
+// attributes absent from configuration keep their prior value.
+func (r *testResource) proposedNewState(config tftypes.Value) tftypes.Value {
+	var configAttrs, priorAttrs map[string]tftypes.Value
+	_ = config.As(&configAttrs)
+	if !r.state.IsNull() {
+		_ = r.state.As(&priorAttrs)
+	}
+
+	proposed := map[string]tftypes.Value{}
+	for _, attr := range r.schema.Block.Attributes {
+		v := configAttrs[attr.Name]
+		if v.IsNull() && attr.Computed {
+			if prior, ok := priorAttrs[attr.Name]; ok {
+				v = prior
+			}
+		}
+		proposed[attr.Name] = v
+	}
+
+	return tftypes.NewValue(r.schema.ValueType(), proposed)
+}
+
+func (r *testResource) unmarshal(dv *tfprotov6.DynamicValue) tftypes.Value {
+	r.h.t.Helper()
+
+	v, err := dv.Unmarshal(r.schema.ValueType())
+	if err != nil {
+		r.h.t.Fatalf("Unmarshal: %s", err)
+	}
+
+	return v
+}
+
+// attr returns an attribute of the current state.
+func (r *testResource) attr(name string) tftypes.Value {
+	var attrs map[string]tftypes.Value
+	if err := r.state.As(&attrs); err != nil {
+		r.h.t.Fatalf("state As: %s", err)
+	}
+
+	return attrs[name]
+}
+
+// stringAttr returns a string attribute of the current state, "" when null.
+func (r *testResource) stringAttr(name string) string {
+	var s string
+	v := r.attr(name)
+	if v.IsNull() {
+		return ""
+	}
+	if err := v.As(&s); err != nil {
+		r.h.t.Fatalf("attribute %q As: %s", name, err)
+	}
+
+	return s
+}
+
+func tfString(s string) tftypes.Value {
+	return tftypes.NewValue(tftypes.String, s)
+}
+
+func tfBool(b bool) tftypes.Value {
+	return tftypes.NewValue(tftypes.Bool, b)
+}
+
+func hasError(diags []*tfprotov6.Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func diagnosticsString(diags []*tfprotov6.Diagnostic) string {
+	var b strings.Builder
+	for _, d := range diags {
+		fmt.Fprintf(&b, "%s: %s: %s\n", d.Severity, d.Summary, d.Detail)
+	}
+
+	return b.String()
+}
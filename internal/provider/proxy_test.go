@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// testProxy is an HTTP proxy that records the Proxy-Authorization header of
+// every request and tunnels CONNECT requests.
+type testProxy struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	authorizations []string
+}
+
+func newTestProxy(t *testing.T) *testProxy {
+	t.Helper()
+
+	p := &testProxy{}
+	p.Server = httptest.NewServer(http.HandlerFunc(p.serveHTTP))
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+func (p *testProxy) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.authorizations = append(p.authorizations, r.Header.Get("Proxy-Authorization"))
+	p.mu.Unlock()
+
+	if r.Method != http.MethodConnect {
+		_, _ = io.WriteString(w, "proxied")
+		return
+	}
+
+	upstream, err := net.Dial("tcp", r.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		upstream.Close()
+	}()
+	go func() {
+		_, _ = io.Copy(conn, upstream)
+		conn.Close()
+	}()
+}
+
+func (p *testProxy) lastAuthorization(t *testing.T) string {
+	t.Helper()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.authorizations) == 0 {
+		t.Fatal("no request went through the proxy")
+	}
+
+	return p.authorizations[len(p.authorizations)-1]
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+func TestProxy_Authorization(t *testing.T) {
+	mock := newMockInfobip(t)
+	proxy := newTestProxy(t)
+
+	// Configure performs a health check request through the proxy.
+	newTestHarness(t, mock, map[string]tftypes.Value{
+		"proxy_url":      tfString(proxy.URL),
+		"proxy_username": tfString("corp-user"),
+		"proxy_password": tfString("s3cret"),
+	})
+
+	if got, want := proxy.lastAuthorization(t), basicAuth("corp-user", "s3cret"); got != want {
+		t.Errorf("Proxy-Authorization = %q, want %q", got, want)
+	}
+}
+
+func TestWithProxy_Credentials(t *testing.T) {
+	proxy := newTestProxy(t)
+
+	cases := map[string]struct {
+		proxyURL           string
+		username, password string
+		want               string
+	}{
+		"none":           {proxyURL: proxy.URL},
+		"in url":         {proxyURL: strings.Replace(proxy.URL, "://", "://url-user:url-pass@", 1), want: basicAuth("url-user", "url-pass")},
+		"attributes":     {proxyURL: proxy.URL, username: "corp-user", password: "s3cret", want: basicAuth("corp-user", "s3cret")},
+		"attributes win": {proxyURL: strings.Replace(proxy.URL, "://", "://url-user:url-pass@", 1), username: "corp-user", password: "s3cret", want: basicAuth("corp-user", "s3cret")},
+		"empty password": {proxyURL: proxy.URL, username: "corp-user", want: basicAuth("corp-user", "")},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			proxyURL, err := parseProxyURL(tc.proxyURL)
+			if err != nil {
+				t.Fatalf("parseProxyURL: %s", err)
+			}
+			client, err := withProxy(&http.Client{}, proxyURL, tc.username, tc.password)
+			if err != nil {
+				t.Fatalf("withProxy: %s", err)
+			}
+
+			resp, err := client.Get("http://api.infobip.invalid/email/1/templates")
+			if err != nil {
+				t.Fatalf("request: %s", err)
+			}
+			resp.Body.Close()
+
+			if got := proxy.lastAuthorization(t); got != tc.want {
+				t.Errorf("Proxy-Authorization = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProxy_InvalidConfig(t *testing.T) {
+	cases := map[string]struct {
+		config map[string]tftypes.Value
+		want   string
+	}{
+		"unsupported scheme": {
+			config: map[string]tftypes.Value{"proxy_url": tfString("ftp://proxy.example.com")},
+			want:   "Invalid proxy url",
+		},
+		"missing host": {
+			config: map[string]tftypes.Value{"proxy_url": tfString("http://")},
+			want:   "Invalid proxy url",
+		},
+		"password without username": {
+			config: map[string]tftypes.Value{"proxy_password": tfString("s3cret")},
+			want:   "Missing proxy username",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := configureTestHarness(t, newMockInfobip(t), tc.config)
+			if !strings.Contains(diagnosticsString(diags), tc.want) {
+				t.Errorf("diagnostics = %s, want %s", diagnosticsString(diags), tc.want)
+			}
+		})
+	}
+}
+
+func TestProxy_PasswordMaskedInLogs(t *testing.T) {
+	mock := newMockInfobip(t)
+	mock.addTemplate(email.CreateEmailTemplateResponse{Name: "Launch s3cret", Subject: "Launch"})
+	proxy := newTestProxy(t)
+	h := newTestHarness(t, mock, map[string]tftypes.Value{
+		"proxy_url":      tfString(proxy.URL),
+		"proxy_username": tfString("corp-user"),
+		"proxy_password": tfString("s3cret"),
+	})
+	var output bytes.Buffer
+	h.ctx = tflogtest.RootLogger(context.Background(), &output)
+	r := h.resource("pocinfobipemails_email_template")
+
+	// Adopting a template logs its name, which contains the password.
+	if diags := r.apply(testEmailTemplateConfig(map[string]tftypes.Value{
+		"name":              tfString("Launch s3cret"),
+		"create_if_missing": tfBool(true),
+	})); hasError(diags) {
+		t.Fatalf("create: %s", diagnosticsString(diags))
+	}
+
+	logged := output.String()
+	if !strings.Contains(logged, "Adopting existing email template") {
+		t.Fatalf("expected the adoption to be logged: %s", logged)
+	}
+	if strings.Contains(logged, "s3cret") {
+		t.Errorf("log leaks the proxy password: %s", logged)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// rateLimitHeaders are the response headers in which Infobip reports the
+// rate limit of the account, in the order they are reported.
+var rateLimitHeaders = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"}
+
+// rateLimitsContextKey is the context key of the *rateLimits a method
+// collects the rate limit headers of its requests in.
+type rateLimitsContextKey struct{}
+
+// rateLimits holds the rate limit headers of the last response that had
+// any, among the requests of a single resource or data source method.
+type rateLimits struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// collectRateLimits returns a child of ctx in which the rate limit headers
+// of the requests made with it are collected, when enabled is set. Like
+// correlateLogs, it is bound to the context, so the requests must be made
+// with a context derived from it.
+func collectRateLimits(ctx context.Context, enabled bool) context.Context {
+	if !enabled {
+		return ctx
+	}
+
+	return context.WithValue(ctx, rateLimitsContextKey{}, &rateLimits{})
+}
+
+// reportRateLimits adds a warning with the rate limit headers collected in
+// ctx, if any. Terraform has no informational diagnostics, so a warning is
+// the only way to show them; methods defer it before escalateWarnings, so it
+// runs after it and warnings_as_errors does not turn it into an error.
+func reportRateLimits(ctx context.Context, diags *diag.Diagnostics) {
+	limits, ok := ctx.Value(rateLimitsContextKey{}).(*rateLimits)
+	if !ok {
+		return
+	}
+
+	limits.mu.Lock()
+	defer limits.mu.Unlock()
+	if len(limits.values) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, header := range rateLimitHeaders {
+		if value, ok := limits.values[header]; ok {
+			lines = append(lines, fmt.Sprintf("%s: %s", header, value))
+		}
+	}
+	diags.AddWarning(
+		"Infobip Rate Limit",
+		"The last Infobip API response reported:\n"+strings.Join(lines, "\n")+"\n\n"+
+			"This is reported because the provider's surface_rate_limit_headers is set.",
+	)
+}
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &rateLimitTransport{}
+
+// rateLimitTransport records the rate limit headers of every response whose
+// request context collects them.
+type rateLimitTransport struct {
+	next http.RoundTripper
+}
+
+func newRateLimitTransport(next http.RoundTripper) *rateLimitTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &rateLimitTransport{next: next}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	limits, ok := req.Context().Value(rateLimitsContextKey{}).(*rateLimits)
+	if !ok || resp == nil {
+		return resp, err
+	}
+
+	values := map[string]string{}
+	for _, header := range rateLimitHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			values[header] = value
+		}
+	}
+	if len(values) > 0 {
+		limits.mu.Lock()
+		limits.values = values
+		limits.mu.Unlock()
+	}
+
+	return resp, err
+}
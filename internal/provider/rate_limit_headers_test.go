@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSurfaceRateLimitHeaders(t *testing.T) {
+	cases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		want           bool
+	}{
+		"disabled": {},
+		"enabled": {
+			providerConfig: map[string]tftypes.Value{"surface_rate_limit_headers": tfBool(true)},
+			want:           true,
+		},
+		"enabled with warnings_as_errors": {
+			providerConfig: map[string]tftypes.Value{
+				"surface_rate_limit_headers": tfBool(true),
+				"warnings_as_errors":         tfBool(true),
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			mock.headers = http.Header{
+				"X-Ratelimit-Limit":     {"100"},
+				"X-Ratelimit-Remaining": {"97"},
+				"X-Ratelimit-Reset":     {"1760700000"},
+			}
+			h := newTestHarness(t, mock, tc.providerConfig)
+			r := h.resource("pocinfobipemails_email_template")
+
+			resourceDiags := r.apply(testEmailTemplateConfig(nil))
+			if hasError(resourceDiags) {
+				t.Fatalf("create: %s", diagnosticsString(resourceDiags))
+			}
+			_, dataSourceDiags := h.readDataSource("pocinfobipemails_email_template_html", map[string]tftypes.Value{
+				"id": tfString(r.stringAttr("id")),
+			})
+			if hasError(dataSourceDiags) {
+				t.Fatalf("read: %s", diagnosticsString(dataSourceDiags))
+			}
+
+			for source, diags := range map[string][]*tfprotov6.Diagnostic{"resource": resourceDiags, "data source": dataSourceDiags} {
+				got := diagnosticsString(diags)
+				if !tc.want {
+					if strings.Contains(got, "Infobip Rate Limit") {
+						t.Errorf("%s diagnostics = %s, want no rate limit warning", source, got)
+					}
+					continue
+				}
+				for _, header := range []string{"X-RateLimit-Limit: 100", "X-RateLimit-Remaining: 97", "X-RateLimit-Reset: 1760700000"} {
+					if !strings.Contains(got, header) {
+						t.Errorf("%s diagnostics = %s, want them to contain %q", source, got, header)
+					}
+				}
+			}
+		})
+	}
+}
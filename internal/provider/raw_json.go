@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rawJSONValue returns the body of resp, as received from Infobip, for the
+// raw_json attribute when expose is set, and null otherwise. The client
+// buffers the body after decoding it, so it can be read again; it is
+// buffered once more for any later reader.
+func rawJSONValue(expose types.Bool, resp *http.Response) types.String {
+	if !expose.ValueBool() || resp == nil || resp.Body == nil {
+		return types.StringNull()
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(body))
+}
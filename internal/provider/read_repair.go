@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// staleComputedAttributes returns the computed attributes that Read fills
+// from the API and that are null in m, such as attributes added to the
+// schema after m was stored.
+func staleComputedAttributes(m EmailTemplateResourceModel) []string {
+	values := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"effective_from", m.EffectiveFrom},
+		{"effective_preheader", m.EffectivePreheader},
+		{"html_raw", m.HtmlRaw},
+		{"is_html_editable", m.IsHtmlEditable},
+		{"image_preview_url", m.ImagePreviewUrl},
+		{"created_at", m.CreatedAt},
+		{"updated_at", m.UpdatedAt},
+		{"placeholders", m.Placeholders},
+	}
+
+	var stale []string
+	for _, v := range values {
+		if v.value.IsNull() {
+			stale = append(stale, v.name)
+		}
+	}
+
+	return stale
+}
+
+// repairHTMLRaw fills a null html_raw with html, the template html as stored
+// by Infobip. html_raw is otherwise only set when html is sent, so state
+// stored before html_raw existed would keep it null, and every plan would show
+// it changing to the configured html. Infobip stores the html as sent, so the
+// repaired value matches what the next apply would send.
+func repairHTMLRaw(m *EmailTemplateResourceModel, html string) {
+	if m.HtmlRaw.IsNull() || m.HtmlRaw.IsUnknown() {
+		m.HtmlRaw = types.StringValue(html)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpguts"
+)
+
+// sensitiveHeaderName matches the names of custom headers whose values are
+// masked in provider logs and the trace file, such as X-Api-Key or
+// X-Auth-Token.
+var sensitiveHeaderName = regexp.MustCompile(`(?i)auth|cookie|key|password|secret|signature|token`)
+
+// requestHeadersContextKey is the context key of the request_headers of the
+// resource a request is made for.
+type requestHeadersContextKey struct{}
+
+// withRequestHeaders returns a child of ctx whose requests carry headers,
+// the request_headers of a resource, and whose log lines mask the values of
+// the sensitive ones. Null and unknown values are skipped.
+func withRequestHeaders(ctx context.Context, headers types.Map) context.Context {
+	values := map[string]string{}
+	var masked []string
+	for name, v := range headers.Elements() {
+		value, ok := v.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		values[name] = value.ValueString()
+		if sensitiveHeaderName.MatchString(name) && value.ValueString() != "" {
+			masked = append(masked, value.ValueString())
+		}
+	}
+	if len(values) == 0 {
+		return ctx
+	}
+
+	if len(masked) > 0 {
+		ctx = tflog.MaskMessageStrings(ctx, masked...)
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, masked...)
+	}
+
+	return context.WithValue(ctx, requestHeadersContextKey{}, values)
+}
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &requestHeadersTransport{}
+
+// requestHeadersTransport sets the provider's default_headers on every
+// request, and then the request_headers of the resource the request is made
+// for, which override them.
+type requestHeadersTransport struct {
+	next     http.RoundTripper
+	defaults map[string]string
+}
+
+func newRequestHeadersTransport(next http.RoundTripper, defaults map[string]string) *requestHeadersTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &requestHeadersTransport{next: next, defaults: defaults}
+}
+
+func (t *requestHeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers, _ := req.Context().Value(requestHeadersContextKey{}).(map[string]string)
+	if len(t.defaults) == 0 && len(headers) == 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTrippers must not modify the request.
+	req = req.Clone(req.Context())
+	for name, value := range t.defaults {
+		req.Header.Set(name, value)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// Ensure interface compliance.
+var _ validator.Map = headersValidator{}
+
+// reservedHeaderNames are the headers the provider and the Infobip client
+// set themselves, which custom headers must not override, in canonical form.
+var reservedHeaderNames = map[string]bool{
+	"Authorization":     true,
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Host":              true,
+	ifMatchHeader:       true,
+	correlationIDHeader: true,
+	organizationHeader:  true,
+}
+
+// headersValidator rejects header maps with names or values that cannot be
+// sent in an HTTP request, and with reservedHeaderNames. allowOrganization
+// allows organizationHeader, so a template can be managed in another
+// sub-account than organization_id.
+type headersValidator struct {
+	allowOrganization bool
+}
+
+func (v headersValidator) Description(ctx context.Context) string {
+	return "Keys must be valid HTTP header names that the provider does not set itself, and values valid HTTP header values."
+}
+
+func (v headersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v headersValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for name, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok {
+			continue
+		}
+
+		canonical := http.CanonicalHeaderKey(name)
+		switch {
+		case !httpguts.ValidHeaderFieldName(name):
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Invalid Header Name",
+				fmt.Sprintf("%q is not a valid HTTP header name.", name),
+			)
+		case reservedHeaderNames[canonical] && !(v.allowOrganization && canonical == organizationHeader):
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Reserved Header Name",
+				fmt.Sprintf("%q is set by the provider, such as from api_key or organization_id, and cannot be overridden.", name),
+			)
+		case !value.IsUnknown() && !httpguts.ValidHeaderFieldValue(value.ValueString()):
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtMapKey(name),
+				"Invalid Header Value",
+				fmt.Sprintf("The value of header %q contains characters that cannot be sent in an HTTP header, such as a newline.", name),
+			)
+		}
+	}
+}
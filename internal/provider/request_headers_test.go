@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_RequestHeaders(t *testing.T) {
+	mock := newMockInfobip(t)
+	var requests []http.Header
+	mock.onRequest = func(r *http.Request) {
+		requests = append(requests, r.Header.Clone())
+	}
+	traceFile := filepath.Join(t.TempDir(), "infobip.trace")
+	h := newTestHarness(t, mock, map[string]tftypes.Value{
+		"default_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"X-Team": tfString("platform"),
+		}),
+		"trace_file": tfString(traceFile),
+	})
+
+	headers := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"X-Team":      tfString("marketing"),
+		"X-Api-Token": tfString("s3cret-token"),
+	})
+	cases := []struct {
+		name      string
+		config    map[string]tftypes.Value
+		wantTeam  string
+		wantToken string
+	}{
+		{
+			name:      "with request_headers",
+			config:    testEmailTemplateConfig(map[string]tftypes.Value{"request_headers": headers}),
+			wantTeam:  "marketing",
+			wantToken: "s3cret-token",
+		},
+		{
+			name:     "without request_headers",
+			config:   testEmailTemplateConfig(map[string]tftypes.Value{"name": tfString("Goodbye")}),
+			wantTeam: "platform",
+		},
+	}
+
+	for _, tc := range cases {
+		mock.mu.Lock()
+		requests = nil
+		mock.mu.Unlock()
+
+		r := h.resource("pocinfobipemails_email_template")
+		if diags := r.apply(tc.config); hasError(diags) {
+			t.Fatalf("%s: create: %s", tc.name, diagnosticsString(diags))
+		}
+		if diags := r.refresh(); hasError(diags) {
+			t.Fatalf("%s: refresh: %s", tc.name, diagnosticsString(diags))
+		}
+		if diags := r.destroy(); hasError(diags) {
+			t.Fatalf("%s: destroy: %s", tc.name, diagnosticsString(diags))
+		}
+
+		mock.mu.Lock()
+		if len(requests) == 0 {
+			t.Errorf("%s: no requests", tc.name)
+		}
+		for i, header := range requests {
+			if got := header.Get("X-Team"); got != tc.wantTeam {
+				t.Errorf("%s: request %d X-Team = %q, want %q", tc.name, i, got, tc.wantTeam)
+			}
+			if got := header.Get("X-Api-Token"); got != tc.wantToken {
+				t.Errorf("%s: request %d X-Api-Token = %q, want %q", tc.name, i, got, tc.wantToken)
+			}
+		}
+		mock.mu.Unlock()
+	}
+
+	raw, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatalf("reading trace file: %s", err)
+	}
+	if !strings.Contains(string(raw), "X-Api-Token: [REDACTED]") || strings.Contains(string(raw), "s3cret-token") {
+		t.Errorf("trace file does not mask X-Api-Token:\n%s", raw)
+	}
+}
+
+func TestEmailTemplateResource_InvalidRequestHeaders(t *testing.T) {
+	h := newTestHarness(t, newMockInfobip(t), nil)
+	r := h.resource("pocinfobipemails_email_template")
+
+	cases := map[string]struct {
+		headers map[string]tftypes.Value
+		wantErr bool
+	}{
+		"name":         {headers: map[string]tftypes.Value{"X Team": tfString("marketing")}, wantErr: true},
+		"value":        {headers: map[string]tftypes.Value{"X-Team": tfString("marketing\r\nX-Other: injected")}, wantErr: true},
+		"reserved":     {headers: map[string]tftypes.Value{"authorization": tfString("App other-key")}, wantErr: true},
+		"organization": {headers: map[string]tftypes.Value{organizationHeader: tfString("org-7")}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := r.validate(testEmailTemplateConfig(map[string]tftypes.Value{
+				"request_headers": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tc.headers),
+			}))
+			if hasError(diags) != tc.wantErr {
+				t.Errorf("validate diagnostics = %s, want an error %t", diagnosticsString(diags), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestHeadersValidator_DefaultHeadersReserved(t *testing.T) {
+	for _, name := range []string{organizationHeader, "x-correlation-id", "If-Match"} {
+		req := validator.MapRequest{
+			Path:        path.Root("default_headers"),
+			ConfigValue: types.MapValueMust(types.StringType, map[string]attr.Value{name: types.StringValue("value")}),
+		}
+		var resp validator.MapResponse
+		headersValidator{}.ValidateMap(context.Background(), req, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("default_headers %q validated, want a reserved header error", name)
+		}
+	}
+}
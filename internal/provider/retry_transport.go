@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	// networkRetryMax caps the retries of a request that fails with a
+	// transient network error, unless read_max_retries or write_max_retries
+	// is set.
+	networkRetryMax = 3
+	// networkRetryInterval is the delay before the first retry. It doubles
+	// after every retry.
+	networkRetryInterval = 250 * time.Millisecond
+)
+
+// retryLimits caps the retries of read requests, which are always safe to
+// repeat, and of write requests, which are only repeated when the server
+// cannot have received them or the method is idempotent.
+type retryLimits struct {
+	read  int
+	write int
+}
+
+// defaultRetryLimits retries reads and writes alike.
+func defaultRetryLimits() retryLimits {
+	return retryLimits{read: networkRetryMax, write: networkRetryMax}
+}
+
+// forMethod returns the retry limit of requests with method.
+func (l retryLimits) forMethod(method string) int {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return l.read
+	}
+
+	return l.write
+}
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &retryTransport{}
+
+// retryTransport retries requests that fail with a transient network error,
+// such as a connection reset or a temporary DNS failure, which carry no HTTP
+// status. Responses, whatever their status, are returned as is.
+type retryTransport struct {
+	next http.RoundTripper
+	// metrics counts the retries. It may be nil.
+	metrics *providerMetrics
+	limits  retryLimits
+}
+
+func newRetryTransport(next http.RoundTripper, metrics *providerMetrics, limits retryLimits) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &retryTransport{next: next, metrics: metrics, limits: limits}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, err := replayableRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	maxRetries := t.limits.forMethod(req.Method)
+	interval := networkRetryInterval
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if err == nil || attempt > maxRetries || !isRetryableNetworkError(req, err) {
+			return resp, err
+		}
+
+		tflog.Debug(req.Context(), "Retrying Infobip API request after a network error", map[string]any{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-req.Context().Done():
+			return nil, err
+		case <-time.After(interval):
+		}
+		interval *= 2
+		t.metrics.recordRetry()
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// isRetryableNetworkError reports whether err, returned by the round trip
+// of req, is a transient network error worth retrying. Errors that happen
+// before the request is sent, a temporary DNS failure or a refused
+// connection, are retried for every method. Errors that may happen after the
+// server received it, a connection reset or a timeout, are only retried for
+// idempotent methods, so a create is never sent twice. Cancelled requests
+// are not retried.
+//
+// net.Error's Temporary is deprecated and not reliable, so the errors are
+// classified explicitly.
+func isRetryableNetworkError(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	if !isIdempotentMethod(req.Method) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isIdempotentMethod reports whether requests with method can be repeated
+// without changing their effect.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// flakyTransport fails the first failures round trips with err, then
+// delegates to http.DefaultTransport. bodies records the body of every
+// round trip.
+type flakyTransport struct {
+	err      error
+	failures int
+	calls    int
+	bodies   []string
+}
+
+func (t *flakyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		req.Body.Close()
+		t.bodies = append(t.bodies, string(body))
+		req.Body = io.NopCloser(strings.NewReader(string(body)))
+	}
+	if t.calls <= t.failures {
+		return nil, t.err
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func fastNetworkRetries(t *testing.T) {
+	t.Helper()
+
+	interval := networkRetryInterval
+	networkRetryInterval = time.Millisecond
+	t.Cleanup(func() { networkRetryInterval = interval })
+}
+
+func TestRetryTransport(t *testing.T) {
+	fastNetworkRetries(t)
+
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	cases := map[string]struct {
+		method    string
+		err       error
+		failures  int
+		wantCalls int
+		wantErr   bool
+	}{
+		"connection reset then success": {
+			method: http.MethodGet, err: reset, failures: 1, wantCalls: 2,
+		},
+		"connection reset on update": {
+			method: http.MethodPut, err: reset, failures: 2, wantCalls: 3,
+		},
+		"connection reset on create is not retried": {
+			method: http.MethodPost, err: reset, failures: 1, wantCalls: 1, wantErr: true,
+		},
+		"temporary DNS failure on create": {
+			method: http.MethodPost, err: &net.DNSError{Err: "server misbehaving", Name: "api.infobip.com", IsTemporary: true}, failures: 1, wantCalls: 2,
+		},
+		"unknown host": {
+			method: http.MethodGet, err: &net.DNSError{Err: "no such host", Name: "api.infobip.com", IsNotFound: true}, failures: 1, wantCalls: 1, wantErr: true,
+		},
+		"timeout": {
+			method: http.MethodGet, err: &net.OpError{Op: "read", Net: "tcp", Err: timeoutError{}}, failures: 1, wantCalls: 2,
+		},
+		"other error": {
+			method: http.MethodGet, err: errors.New("tls: handshake failure"), failures: 1, wantCalls: 1, wantErr: true,
+		},
+		"retries are capped": {
+			method: http.MethodGet, err: reset, failures: 10, wantCalls: networkRetryMax + 1, wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			flaky := &flakyTransport{err: tc.err, failures: tc.failures}
+			client := &http.Client{Transport: newRetryTransport(flaky, nil, defaultRetryLimits())}
+
+			// A body that cannot be recreated is replayed on every attempt.
+			req, _ := http.NewRequest(tc.method, server.URL, io.NopCloser(strings.NewReader("name=Welcome")))
+			resp, err := client.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tc.wantErr)
+			}
+			if flaky.calls != tc.wantCalls {
+				t.Errorf("round trips = %d, want %d", flaky.calls, tc.wantCalls)
+			}
+			for i, body := range flaky.bodies {
+				if body != "name=Welcome" {
+					t.Errorf("round trip %d body = %q, want the request body", i, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransport_PerOperationLimits(t *testing.T) {
+	fastNetworkRetries(t)
+
+	limits := retryLimits{read: 5, write: 1}
+	cases := map[string]struct {
+		method    string
+		wantCalls int
+	}{
+		"read":  {method: http.MethodGet, wantCalls: limits.read + 1},
+		"write": {method: http.MethodPut, wantCalls: limits.write + 1},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			flaky := &flakyTransport{err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, failures: 10}
+			transport := newRetryTransport(flaky, nil, limits)
+
+			req, _ := http.NewRequest(tc.method, "https://api.infobip.com/email/1/templates/1", nil)
+			if _, err := transport.RoundTrip(req); err == nil {
+				t.Fatal("RoundTrip succeeded, want the network error")
+			}
+			if flaky.calls != tc.wantCalls {
+				t.Errorf("round trips = %d, want %d", flaky.calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestProvider_InvalidMaxRetries(t *testing.T) {
+	for _, name := range []string{"read_max_retries", "write_max_retries"} {
+		t.Run(name, func(t *testing.T) {
+			_, diags := configureTestHarness(t, newMockInfobip(t), map[string]tftypes.Value{name: tftypes.NewValue(tftypes.Number, -1)})
+			if !strings.Contains(diagnosticsString(diags), "Invalid max retries") {
+				t.Errorf("diagnostics = %s, want an invalid max retries error", diagnosticsString(diags))
+			}
+		})
+	}
+}
+
+func TestRetryTransport_Cancelled(t *testing.T) {
+	fastNetworkRetries(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	flaky := &flakyTransport{err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, failures: 10}
+	transport := newRetryTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		cancel()
+		return flaky.RoundTrip(req)
+	}), nil, defaultRetryLimits())
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.infobip.com", nil)
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Fatal("RoundTrip succeeded, want the network error")
+	}
+	if flaky.calls != 1 {
+		t.Errorf("round trips = %d, want 1", flaky.calls)
+	}
+}
+
+// timeoutError is a net.Error that timed out.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
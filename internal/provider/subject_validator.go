@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure interface compliance.
+var _ validator.String = emptySubjectValidator{}
+
+// emptySubjectValidator rejects an empty subject, which Infobip would send
+// as an email without a subject line.
+type emptySubjectValidator struct{}
+
+func (v emptySubjectValidator) Description(ctx context.Context) string {
+	return "Value must not be empty."
+}
+
+func (v emptySubjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emptySubjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if req.ConfigValue.ValueString() == "" {
+		resp.Diagnostics.Append(emptySubjectDiagnostic(req.Path))
+	}
+}
+
+// emptySubjectDiagnostic is the error for an empty subject at p.
+func emptySubjectDiagnostic(p path.Path) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		p,
+		"Empty Email Template Subject",
+		"The subject of an email template must not be empty.",
+	)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"time"
+
+	// Embed the IANA time zone database, so timestamp_tz resolves on
+	// machines without one, such as Windows.
+	_ "time/tzdata"
+)
+
+// timestampLayoutCheck is formatted and parsed back to validate a
+// timestamp_format. It is in the afternoon, so a 12-hour layout without
+// AM or PM does not parse it back.
+var timestampLayoutCheck = time.Date(2009, time.November, 10, 23, 4, 5, 0, time.UTC)
+
+// timestampFormatter formats the timestamps the provider synthesizes, such
+// as created_at when Infobip does not return one. Its zero value formats
+// them as RFC 3339 in UTC.
+type timestampFormatter struct {
+	layout   string
+	location *time.Location
+}
+
+// validateTimestampLayout fails for a timestamp_format layout that does not
+// format a point in time that parses back, such as a layout without the
+// time of day.
+func validateTimestampLayout(layout string) error {
+	parsed, err := time.Parse(layout, timestampLayoutCheck.Format(layout))
+	if err != nil {
+		return err
+	}
+	if !parsed.Equal(timestampLayoutCheck) && !parsed.Equal(timestampLayoutCheck.Truncate(time.Minute)) {
+		return errors.New("the layout must include the date and the time of day, such as 2006-01-02 15:04:05")
+	}
+
+	return nil
+}
+
+// now returns the current time, formatted.
+func (f timestampFormatter) now() string {
+	layout := f.layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	location := f.location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return time.Now().In(location).Format(layout)
+}
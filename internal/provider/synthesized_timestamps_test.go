@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEmailTemplateResource_TimestampFormat(t *testing.T) {
+	cases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		layout         string
+		location       string
+		zone           string
+	}{
+		"default": {
+			layout:   time.RFC3339,
+			location: "UTC",
+			zone:     "UTC",
+		},
+		"custom format and zone": {
+			providerConfig: map[string]tftypes.Value{
+				"timestamp_format": tfString("2006-01-02 15:04:05 MST"),
+				"timestamp_tz":     tfString("Asia/Tokyo"),
+			},
+			layout:   "2006-01-02 15:04:05 MST",
+			location: "Asia/Tokyo",
+			zone:     "JST",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := newMockInfobip(t)
+			h := newTestHarness(t, mock, tc.providerConfig)
+			r := h.resource("pocinfobipemails_email_template")
+
+			location, err := time.LoadLocation(tc.location)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			before := time.Now().Truncate(time.Second)
+			if diags := r.apply(testEmailTemplateConfig(nil)); hasError(diags) {
+				t.Fatalf("create: %s", diagnosticsString(diags))
+			}
+
+			for _, attr := range []string{"created_at", "updated_at"} {
+				value := r.stringAttr(attr)
+				parsed, err := time.ParseInLocation(tc.layout, value, location)
+				if err != nil {
+					t.Fatalf("%s = %q, want the layout %q: %s", attr, value, tc.layout, err)
+				}
+				if zone, _ := parsed.Zone(); zone != tc.zone {
+					t.Errorf("%s = %q, want the zone %s", attr, value, tc.zone)
+				}
+				if parsed.Before(before) || parsed.After(time.Now()) {
+					t.Errorf("%s = %q, want the time of the create", attr, value)
+				}
+			}
+		})
+	}
+}
+
+func TestProvider_InvalidTimestampSettings(t *testing.T) {
+	cases := map[string]struct {
+		providerConfig map[string]tftypes.Value
+		want           string
+	}{
+		"layout without time of day": {
+			providerConfig: map[string]tftypes.Value{"timestamp_format": tfString("2006-01-02")},
+			want:           "Invalid timestamp format",
+		},
+		"not a layout": {
+			providerConfig: map[string]tftypes.Value{"timestamp_format": tfString("YYYY-MM-DD hh:mm:ss")},
+			want:           "Invalid timestamp format",
+		},
+		"unknown zone": {
+			providerConfig: map[string]tftypes.Value{"timestamp_tz": tfString("Mars/Olympus_Mons")},
+			want:           "Invalid timestamp time zone",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, diags := configureTestHarness(t, newMockInfobip(t), tc.providerConfig)
+			if !strings.Contains(diagnosticsString(diags), tc.want) {
+				t.Errorf("diagnostics = %s, want %q", diagnosticsString(diags), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"time"
+)
+
+// parseTemplateTimestamp parses the created_at or updated_at of a template,
+// which Infobip reports as RFC 3339 or, for older templates, in one of
+// legacyTimestampLayouts.
+func parseTemplateTimestamp(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	for _, layout := range legacyTimestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// filterModifiedSince returns the templates updated after since. Templates
+// whose updated_at is missing or cannot be parsed are kept, so incremental
+// syncs never miss a change; their IDs are returned too.
+func filterModifiedSince(templates []EmailTemplatesDataSourceTemplate, since time.Time) (kept []EmailTemplatesDataSourceTemplate, unknown []string) {
+	kept = []EmailTemplatesDataSourceTemplate{}
+	for _, template := range templates {
+		updatedAt, ok := parseTemplateTimestamp(template.UpdatedAt.ValueString())
+		if !ok {
+			unknown = append(unknown, template.ID.ValueString())
+			kept = append(kept, template)
+			continue
+		}
+		if updatedAt.After(since) {
+			kept = append(kept, template)
+		}
+	}
+
+	return kept, unknown
+}
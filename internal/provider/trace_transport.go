@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceFileMaxBytes caps the trace file size; once exceeded the file is
+// rotated to "<trace_file>.1", replacing any previous rotation.
+const traceFileMaxBytes = 10 << 20
+
+// Ensure interface compliance.
+var _ http.RoundTripper = &traceTransport{}
+
+var traceAuthorizationHeader = regexp.MustCompile(`(?im)^(Authorization:[ \t]*)[^\r\n]*`)
+
+// traceTransport writes every request/response pair to a file, with
+// credentials redacted, so they can be attached to Infobip support tickets.
+type traceTransport struct {
+	next     http.RoundTripper
+	path     string
+	apiKey   string
+	maxBytes int64
+
+	mu sync.Mutex
+}
+
+func newTraceTransport(next http.RoundTripper, path, apiKey string) *traceTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &traceTransport{
+		next:     next,
+		path:     path,
+		apiKey:   apiKey,
+		maxBytes: traceFileMaxBytes,
+	}
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s request ===\n", start.UTC().Format(time.RFC3339Nano))
+	if dump, err := httputil.DumpRequestOut(req, true); err != nil {
+		fmt.Fprintf(&b, "(could not dump request: %s)\n", err)
+	} else {
+		b.Write(dump)
+		b.WriteString("\n")
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	fmt.Fprintf(&b, "=== response after %s ===\n", time.Since(start))
+	switch {
+	case err != nil:
+		fmt.Fprintf(&b, "(transport error: %s)\n", err)
+	default:
+		if dump, dumpErr := httputil.DumpResponse(resp, true); dumpErr != nil {
+			fmt.Fprintf(&b, "(could not dump response: %s)\n", dumpErr)
+		} else {
+			b.Write(dump)
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	// Tracing is best effort and must never fail the API call.
+	_ = t.write(t.redact(b.String(), req.Header))
+
+	return resp, err
+}
+
+// redact removes the Authorization header value, any literal API key and
+// the values of the sensitive custom headers of header.
+func (t *traceTransport) redact(s string, header http.Header) string {
+	s = traceAuthorizationHeader.ReplaceAllString(s, "${1}[REDACTED]")
+	if t.apiKey != "" {
+		s = strings.ReplaceAll(s, t.apiKey, "[REDACTED]")
+	}
+	for name, values := range header {
+		if !sensitiveHeaderName.MatchString(name) {
+			continue
+		}
+		for _, value := range values {
+			if value != "" {
+				s = strings.ReplaceAll(s, value, "[REDACTED]")
+			}
+		}
+	}
+
+	return s
+}
+
+func (t *traceTransport) write(entry string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if info, err := os.Stat(t.path); err == nil && info.Size()+int64(len(entry)) > t.maxBytes {
+		if err := os.Rename(t.path, t.path+".1"); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(t.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(entry)
+
+	return err
+}
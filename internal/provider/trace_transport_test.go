@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTraceTransport_WritesRedactedEntry(t *testing.T) {
+	mock := newMockInfobip(t)
+	traceFile := filepath.Join(t.TempDir(), "infobip.trace")
+
+	// Configure performs a health check request through the trace transport.
+	newTestHarness(t, mock, map[string]tftypes.Value{
+		"trace_file": tfString(traceFile),
+	})
+
+	raw, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatalf("reading trace file: %s", err)
+	}
+	trace := string(raw)
+
+	for _, want := range []string{"GET /email/1/templates", "Authorization: [REDACTED]", "HTTP/1.1 200 OK", `"results"`} {
+		if !strings.Contains(trace, want) {
+			t.Errorf("trace file missing %q:\n%s", want, trace)
+		}
+	}
+	if strings.Contains(trace, "test-key") {
+		t.Errorf("trace file leaks the API key:\n%s", trace)
+	}
+}
+
+func TestTraceTransport_Rotates(t *testing.T) {
+	mock := newMockInfobip(t)
+	traceFile := filepath.Join(t.TempDir(), "infobip.trace")
+
+	transport := newTraceTransport(mock.Client().Transport, traceFile, "test-key")
+	transport.maxBytes = 1024
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(mock.URL + "/email/1/templates")
+		if err != nil {
+			t.Fatalf("request %d: %s", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	info, err := os.Stat(traceFile)
+	if err != nil {
+		t.Fatalf("stat trace file: %s", err)
+	}
+	if info.Size() > transport.maxBytes {
+		t.Errorf("trace file size = %d, want at most %d", info.Size(), transport.maxBytes)
+	}
+	if _, err := os.Stat(traceFile + ".1"); err != nil {
+		t.Errorf("expected rotated trace file: %s", err)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/text/unicode/norm"
+)
+
+// nfcEqual reports whether a and b are known, non-null and canonically
+// equivalent, such as a precomposed "é" and an "e" followed by a combining
+// acute accent. Canonically equivalent strings render identically, so only
+// the encoding differs; compatibility forms such as "ﬁ" and "fi" are not
+// equated.
+func nfcEqual(a, b types.String) bool {
+	if a.IsUnknown() || a.IsNull() || b.IsUnknown() || b.IsNull() {
+		return false
+	}
+
+	return norm.NFC.String(a.ValueString()) == norm.NFC.String(b.ValueString())
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/api"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UnmanagedTemplatesDataSource{}
+
+func NewUnmanagedTemplatesDataSource() datasource.DataSource {
+	return &UnmanagedTemplatesDataSource{}
+}
+
+// UnmanagedTemplatesDataSource lists the templates of the account that are
+// not in a given set of managed ids, for drift audits.
+type UnmanagedTemplatesDataSource struct {
+	infobipClient *api.APIClient
+	apiKey        string
+	authSchemeKey string
+	// logRedactPatterns are masked in every log line.
+	logRedactPatterns []*regexp.Regexp
+	// correlationID is added to every log line.
+	correlationID string
+	// organizationID scopes every request to an Infobip organization.
+	organizationID string
+	// warningsAsErrors reports the warnings of every method as errors.
+	warningsAsErrors bool
+	// surfaceRateLimitHeaders reports the rate limit headers of the API
+	// responses of every method.
+	surfaceRateLimitHeaders bool
+}
+
+// UnmanagedTemplatesDataSourceModel describes the data source data model.
+type UnmanagedTemplatesDataSourceModel struct {
+	ManagedIDs []string                            `tfsdk:"managed_ids"`
+	Templates  []UnmanagedTemplatesDataSourceEntry `tfsdk:"templates"`
+}
+
+// UnmanagedTemplatesDataSourceEntry describes a single unmanaged template.
+type UnmanagedTemplatesDataSourceEntry struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *UnmanagedTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unmanaged_templates"
+}
+
+func (d *UnmanagedTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Infobip Email Templates of the account whose ids are not in `managed_ids`, " +
+			"such as templates created outside of Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"managed_ids": schema.SetAttribute{
+				Description: "Ids of the templates managed elsewhere, typically the `id` of every `pocinfobipemails_email_template` resource.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"templates": schema.ListNestedAttribute{
+				Description: "Email templates of the account that are not in `managed_ids`.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the email template.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the email template.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UnmanagedTemplatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.infobipClient = pd.client
+	d.apiKey = pd.apiKey
+	d.authSchemeKey = pd.authSchemeKey
+	d.logRedactPatterns = pd.logRedactPatterns
+	d.correlationID = pd.correlationID
+	d.organizationID = pd.organizationID
+	d.warningsAsErrors = pd.warningsAsErrors
+	d.surfaceRateLimitHeaders = pd.surfaceRateLimitHeaders
+}
+
+func (d *UnmanagedTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = redactLogs(ctx, d.logRedactPatterns)
+	ctx = correlateLogs(ctx, d.correlationID)
+	ctx = collectRateLimits(ctx, d.surfaceRateLimitHeaders)
+	defer reportRateLimits(ctx, &resp.Diagnostics)
+	defer escalateWarnings(d.warningsAsErrors, &resp.Diagnostics)
+
+	var data UnmanagedTemplatesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := listEmailTemplates(infobipAuthContext(ctx, d.apiKey, d.authSchemeKey, d.organizationID), d.infobipClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Email Templates",
+			"An error was encountered while listing email templates: "+err.Error(),
+		)
+		return
+	}
+
+	managed := make(map[string]bool, len(data.ManagedIDs))
+	for _, id := range data.ManagedIDs {
+		managed[id] = true
+	}
+
+	templates := []UnmanagedTemplatesDataSourceEntry{}
+	for _, item := range items {
+		id := fmt.Sprintf("%d", item.GetId())
+		if managed[id] {
+			continue
+		}
+
+		templates = append(templates, UnmanagedTemplatesDataSourceEntry{
+			ID:   types.StringValue(id),
+			Name: types.StringValue(item.GetName()),
+		})
+	}
+
+	data.Templates = templates
+	tflog.Trace(ctx, "read unmanaged templates data source", map[string]any{"listed": len(items), "unmanaged": len(templates)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
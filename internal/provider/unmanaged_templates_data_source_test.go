@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/framebassman/infobip-api-go-client/v3/pkg/infobip/models/email"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUnmanagedTemplatesDataSource(t *testing.T) {
+	mock := newMockInfobip(t)
+	ids := map[string]string{}
+	for _, name := range []string{"welcome", "reset", "legacy", "manual"} {
+		ids[name] = fmt.Sprintf("%d", mock.addTemplate(email.CreateEmailTemplateResponse{Name: name, Subject: name}))
+	}
+	h := newTestHarness(t, mock, nil)
+
+	state, diags := h.readDataSource("pocinfobipemails_unmanaged_templates", map[string]tftypes.Value{
+		"managed_ids": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tfString(ids["welcome"]),
+			tfString(ids["reset"]),
+			// Ids that no longer exist are ignored.
+			tfString("999"),
+		}),
+	})
+	if hasError(diags) {
+		t.Fatalf("read: %s", diagnosticsString(diags))
+	}
+
+	got := listedTemplates(t, state)
+	want := map[string]string{ids["legacy"]: "legacy", ids["manual"]: "manual"}
+	if len(got) != len(want) {
+		t.Fatalf("unmanaged templates = %v, want %v", got, want)
+	}
+	for id, name := range want {
+		if got[id]["name"] != name {
+			t.Errorf("template %s = %v, want name %q", id, got[id], name)
+		}
+	}
+}
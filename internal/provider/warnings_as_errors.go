@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// escalatedWarningNote is appended to the detail of warnings reported as
+// errors.
+const escalatedWarningNote = "This warning is reported as an error because the provider's warnings_as_errors is set."
+
+// escalateWarnings replaces the warnings in diags with errors of the same
+// summary, detail and attribute path when enabled is set. Resources and data
+// sources defer it on their response diagnostics, so only the warnings they
+// issue themselves are escalated, not those the framework adds around them.
+func escalateWarnings(enabled bool, diags *diag.Diagnostics) {
+	if !enabled || diags.WarningsCount() == 0 {
+		return
+	}
+
+	escalated := make(diag.Diagnostics, 0, len(*diags))
+	for _, d := range *diags {
+		if d.Severity() != diag.SeverityWarning {
+			escalated = append(escalated, d)
+			continue
+		}
+
+		detail := escalatedWarningNote
+		if d.Detail() != "" {
+			detail = d.Detail() + "\n\n" + escalatedWarningNote
+		}
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			escalated = append(escalated, diag.NewAttributeErrorDiagnostic(withPath.Path(), d.Summary(), detail))
+		} else {
+			escalated = append(escalated, diag.NewErrorDiagnostic(d.Summary(), detail))
+		}
+	}
+
+	*diags = escalated
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEscalateWarnings(t *testing.T) {
+	var diags diag.Diagnostics
+	diags.AddAttributeWarning(path.Root("html"), "HTML Lint Warning", "img is missing alt")
+	diags.AddWarning("Preview Image Not Ready", "")
+	diags.AddError("Error Creating Email Template", "boom")
+
+	escalateWarnings(false, &diags)
+	if diags.WarningsCount() != 2 {
+		t.Fatalf("disabled: got %d warnings, want 2", diags.WarningsCount())
+	}
+
+	escalateWarnings(true, &diags)
+	if diags.WarningsCount() != 0 || diags.ErrorsCount() != 3 {
+		t.Fatalf("got %d warnings and %d errors, want 0 and 3: %v", diags.WarningsCount(), diags.ErrorsCount(), diags)
+	}
+
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("html")) {
+		t.Errorf("escalated warning lost its path: %v", diags[0])
+	}
+	if got := diags[0].Detail(); !strings.HasPrefix(got, "img is missing alt\n\n") || !strings.Contains(got, "warnings_as_errors") {
+		t.Errorf("escalated detail = %q", got)
+	}
+	if got := diags[1].Detail(); got != escalatedWarningNote {
+		t.Errorf("escalated empty detail = %q, want %q", got, escalatedWarningNote)
+	}
+	if got := diags[2].Detail(); got != "boom" {
+		t.Errorf("error detail = %q, want it unchanged", got)
+	}
+}
+
+func TestEmailTemplateResource_WarningsAsErrors(t *testing.T) {
+	config := testEmailTemplateConfig(map[string]tftypes.Value{
+		"from":     tfString("support@example.com"),
+		"reply_to": tfString("support@example.com"),
+	})
+
+	for _, strict := range []bool{false, true} {
+		mock := newMockInfobip(t)
+		h := newTestHarness(t, mock, map[string]tftypes.Value{
+			"lint":               tfBool(true),
+			"warnings_as_errors": tfBool(strict),
+		})
+		r := h.resource("pocinfobipemails_email_template")
+
+		diags := r.apply(config)
+		if hasError(diags) != strict {
+			t.Fatalf("warnings_as_errors=%t: diagnostics = %s", strict, diagnosticsString(diags))
+		}
+		if !strings.Contains(diagnosticsString(diags), "Redundant Reply-To Address") {
+			t.Errorf("warnings_as_errors=%t: diagnostics = %s, want the lint warning", strict, diagnosticsString(diags))
+		}
+		if created := len(mock.templates) == 1; created == strict {
+			t.Errorf("warnings_as_errors=%t: template created = %t", strict, created)
+		}
+	}
+}
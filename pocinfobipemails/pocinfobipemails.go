@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package pocinfobipemails exposes the provider for embedding in other
+// programs, such as a provider built with terraform-plugin-mux.
+package pocinfobipemails
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	internal "terraform-provider-pocinfobipemails/internal/provider"
+)
+
+// New returns a function that creates the provider with the given version.
+func New(version string) func() provider.Provider {
+	return internal.New(version)
+}
+
+// ProtocolV6ServerFactory returns a factory for a protocol version 6 provider
+// server that can be muxed with other providers or served in-process.
+func ProtocolV6ServerFactory(version string) func() tfprotov6.ProviderServer {
+	return internal.ProtocolV6ServerFactory(version)
+}